@@ -10,10 +10,70 @@ It is generated from these files:
 
 It has these top-level messages:
 	Device
+	Sensor
+	PowerReading
 	ListDevicesRequest
 	ListDevicesResponse
 	GetDeviceRequest
 	UpdateDeviceRequest
+	WatchDevicesRequest
+	DeviceEvent
+	RenameDeviceRequest
+	SetDeviceNamesRequest
+	Group
+	CreateGroupRequest
+	ListGroupsRequest
+	ListGroupsResponse
+	UpdateGroupRequest
+	DeleteGroupRequest
+	DeleteGroupResponse
+	SetGroupStateRequest
+	SetGroupStateResponse
+	DeviceResult
+	Scene
+	SceneDevice
+	CreateSceneRequest
+	ListScenesRequest
+	ListScenesResponse
+	DeleteSceneRequest
+	DeleteSceneResponse
+	ActivateSceneRequest
+	ActivateSceneResponse
+	Action
+	Schedule
+	CreateScheduleRequest
+	ListSchedulesRequest
+	ListSchedulesResponse
+	DeleteScheduleRequest
+	DeleteScheduleResponse
+	GetSolarTimesRequest
+	SolarTimes
+	Rule
+	Trigger
+	Condition
+	CreateRuleRequest
+	GetRuleRequest
+	ListRulesRequest
+	ListRulesResponse
+	UpdateRuleRequest
+	DeleteRuleRequest
+	DeleteRuleResponse
+	ListScriptsRequest
+	ListScriptsResponse
+	Script
+	SetDeviceStateForRequest
+	Timer
+	ListTimersRequest
+	ListTimersResponse
+	CancelTimerRequest
+	CancelTimerResponse
+	HistoryRecord
+	QueryHistoryRequest
+	QueryHistoryResponse
+	AddDeviceRequest
+	AddDeviceResponse
+	ForgetDeviceRequest
+	ForgetDeviceResponse
 */
 package apartment
 
@@ -37,10 +97,112 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type PowerState int32
+
+const (
+	PowerState_UNKNOWN PowerState = 0
+	PowerState_OFF     PowerState = 1
+	PowerState_ON      PowerState = 2
+	// On, but drawing less than the standby threshold.
+	PowerState_STANDBY PowerState = 3
+)
+
+var PowerState_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "OFF",
+	2: "ON",
+	3: "STANDBY",
+}
+var PowerState_value = map[string]int32{
+	"UNKNOWN": 0,
+	"OFF":     1,
+	"ON":      2,
+	"STANDBY": 3,
+}
+
+func (x PowerState) String() string {
+	return proto.EnumName(PowerState_name, int32(x))
+}
+func (PowerState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type Device_Type int32
+
+const (
+	Device_OTHER        Device_Type = 0
+	Device_SWITCH       Device_Type = 1
+	Device_INSIGHT      Device_Type = 2
+	Device_DIMMER       Device_Type = 3
+	Device_LIGHT_SWITCH Device_Type = 4
+	Device_MAKER        Device_Type = 5
+	Device_MOTION       Device_Type = 6
+	// LED bulb paired with a WeMo Link bridge.
+	Device_BULB Device_Type = 7
+)
+
+var Device_Type_name = map[int32]string{
+	0: "OTHER",
+	1: "SWITCH",
+	2: "INSIGHT",
+	3: "DIMMER",
+	4: "LIGHT_SWITCH",
+	5: "MAKER",
+	6: "MOTION",
+	7: "BULB",
+}
+var Device_Type_value = map[string]int32{
+	"OTHER":        0,
+	"SWITCH":       1,
+	"INSIGHT":      2,
+	"DIMMER":       3,
+	"LIGHT_SWITCH": 4,
+	"MAKER":        5,
+	"MOTION":       6,
+	"BULB":         7,
+}
+
+func (x Device_Type) String() string {
+	return proto.EnumName(Device_Type_name, int32(x))
+}
+func (Device_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 0} }
+
 type Device struct {
 	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	FriendlyName string `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName" json:"friendly_name,omitempty"`
 	State        bool   `protobuf:"varint,3,opt,name=state" json:"state,omitempty"`
+	// Energy readings, only set for devices with metering (WeMo Insight).
+	Power *PowerReading `protobuf:"bytes,4,opt,name=power" json:"power,omitempty"`
+	// Detailed state, state is true for both ON and STANDBY.
+	PowerState PowerState `protobuf:"varint,5,opt,name=power_state,json=powerState,enum=apartment.PowerState" json:"power_state,omitempty"`
+	// Brightness from 1 to 100, only used for dimmable devices.
+	Brightness int32       `protobuf:"varint,6,opt,name=brightness" json:"brightness,omitempty"`
+	Dimmable   bool        `protobuf:"varint,7,opt,name=dimmable" json:"dimmable,omitempty"`
+	Type       Device_Type `protobuf:"varint,8,opt,name=type,enum=apartment.Device.Type" json:"type,omitempty"`
+	// Read-only sensor input, only set for devices with one (WeMo Maker, Motion).
+	Sensor *Sensor `protobuf:"bytes,9,opt,name=sensor" json:"sensor,omitempty"`
+	// The device turns itself off after a short pulse when turned on.
+	Momentary bool `protobuf:"varint,10,opt,name=momentary" json:"momentary,omitempty"`
+	// The device is a sensor and cannot be updated.
+	ReadOnly bool `protobuf:"varint,11,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+	// Color temperature in Kelvin, only used for tunable bulbs.
+	ColorTemperature int32 `protobuf:"varint,12,opt,name=color_temperature,json=colorTemperature" json:"color_temperature,omitempty"`
+	Tunable          bool  `protobuf:"varint,13,opt,name=tunable" json:"tunable,omitempty"`
+	// Metadata from the device's setup.xml.
+	// The UDN uniquely identifies a device and may be used in place of its name.
+	Udn             string `protobuf:"bytes,14,opt,name=udn" json:"udn,omitempty"`
+	SerialNumber    string `protobuf:"bytes,15,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	MacAddress      string `protobuf:"bytes,16,opt,name=mac_address,json=macAddress" json:"mac_address,omitempty"`
+	ModelName       string `protobuf:"bytes,17,opt,name=model_name,json=modelName" json:"model_name,omitempty"`
+	ModelNumber     string `protobuf:"bytes,18,opt,name=model_number,json=modelNumber" json:"model_number,omitempty"`
+	FirmwareVersion string `protobuf:"bytes,19,opt,name=firmware_version,json=firmwareVersion" json:"firmware_version,omitempty"`
+	// UPnP device type, e.g. urn:Belkin:device:insight:1.
+	DeviceType string `protobuf:"bytes,20,opt,name=device_type,json=deviceType" json:"device_type,omitempty"`
+	IconUrl    string `protobuf:"bytes,21,opt,name=icon_url,json=iconUrl" json:"icon_url,omitempty"`
+	// Server-side names, independent of the device's friendly name.
+	DisplayName string `protobuf:"bytes,22,opt,name=display_name,json=displayName" json:"display_name,omitempty"`
+	// Alternative names the device can be looked up by.
+	Aliases []string `protobuf:"bytes,23,rep,name=aliases" json:"aliases,omitempty"`
+	// Pending timer reverting the device, if any.
+	Timer *Timer `protobuf:"bytes,24,opt,name=timer" json:"timer,omitempty"`
 }
 
 func (m *Device) Reset()                    { *m = Device{} }
@@ -69,13 +231,235 @@ func (m *Device) GetState() bool {
 	return false
 }
 
+func (m *Device) GetPower() *PowerReading {
+	if m != nil {
+		return m.Power
+	}
+	return nil
+}
+
+func (m *Device) GetPowerState() PowerState {
+	if m != nil {
+		return m.PowerState
+	}
+	return PowerState_UNKNOWN
+}
+
+func (m *Device) GetBrightness() int32 {
+	if m != nil {
+		return m.Brightness
+	}
+	return 0
+}
+
+func (m *Device) GetDimmable() bool {
+	if m != nil {
+		return m.Dimmable
+	}
+	return false
+}
+
+func (m *Device) GetType() Device_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Device_OTHER
+}
+
+func (m *Device) GetSensor() *Sensor {
+	if m != nil {
+		return m.Sensor
+	}
+	return nil
+}
+
+func (m *Device) GetMomentary() bool {
+	if m != nil {
+		return m.Momentary
+	}
+	return false
+}
+
+func (m *Device) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *Device) GetColorTemperature() int32 {
+	if m != nil {
+		return m.ColorTemperature
+	}
+	return 0
+}
+
+func (m *Device) GetTunable() bool {
+	if m != nil {
+		return m.Tunable
+	}
+	return false
+}
+
+func (m *Device) GetUdn() string {
+	if m != nil {
+		return m.Udn
+	}
+	return ""
+}
+
+func (m *Device) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *Device) GetMacAddress() string {
+	if m != nil {
+		return m.MacAddress
+	}
+	return ""
+}
+
+func (m *Device) GetModelName() string {
+	if m != nil {
+		return m.ModelName
+	}
+	return ""
+}
+
+func (m *Device) GetModelNumber() string {
+	if m != nil {
+		return m.ModelNumber
+	}
+	return ""
+}
+
+func (m *Device) GetFirmwareVersion() string {
+	if m != nil {
+		return m.FirmwareVersion
+	}
+	return ""
+}
+
+func (m *Device) GetDeviceType() string {
+	if m != nil {
+		return m.DeviceType
+	}
+	return ""
+}
+
+func (m *Device) GetIconUrl() string {
+	if m != nil {
+		return m.IconUrl
+	}
+	return ""
+}
+
+func (m *Device) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *Device) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *Device) GetTimer() *Timer {
+	if m != nil {
+		return m.Timer
+	}
+	return nil
+}
+
+type Sensor struct {
+	// Whether anything is connected to the input.
+	Present   bool `protobuf:"varint,1,opt,name=present" json:"present,omitempty"`
+	Triggered bool `protobuf:"varint,2,opt,name=triggered" json:"triggered,omitempty"`
+}
+
+func (m *Sensor) Reset()                    { *m = Sensor{} }
+func (m *Sensor) String() string            { return proto.CompactTextString(m) }
+func (*Sensor) ProtoMessage()               {}
+func (*Sensor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Sensor) GetPresent() bool {
+	if m != nil {
+		return m.Present
+	}
+	return false
+}
+
+func (m *Sensor) GetTriggered() bool {
+	if m != nil {
+		return m.Triggered
+	}
+	return false
+}
+
+type PowerReading struct {
+	// Instantaneous power draw in milliwatts.
+	CurrentMw      int64   `protobuf:"varint,1,opt,name=current_mw,json=currentMw" json:"current_mw,omitempty"`
+	TodayKwh       float64 `protobuf:"fixed64,2,opt,name=today_kwh,json=todayKwh" json:"today_kwh,omitempty"`
+	TotalKwh       float64 `protobuf:"fixed64,3,opt,name=total_kwh,json=totalKwh" json:"total_kwh,omitempty"`
+	OnTodaySeconds int64   `protobuf:"varint,4,opt,name=on_today_seconds,json=onTodaySeconds" json:"on_today_seconds,omitempty"`
+	// Power draw in milliwatts below which the device is in standby.
+	StandbyThresholdMw int64 `protobuf:"varint,5,opt,name=standby_threshold_mw,json=standbyThresholdMw" json:"standby_threshold_mw,omitempty"`
+}
+
+func (m *PowerReading) Reset()                    { *m = PowerReading{} }
+func (m *PowerReading) String() string            { return proto.CompactTextString(m) }
+func (*PowerReading) ProtoMessage()               {}
+func (*PowerReading) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PowerReading) GetCurrentMw() int64 {
+	if m != nil {
+		return m.CurrentMw
+	}
+	return 0
+}
+
+func (m *PowerReading) GetTodayKwh() float64 {
+	if m != nil {
+		return m.TodayKwh
+	}
+	return 0
+}
+
+func (m *PowerReading) GetTotalKwh() float64 {
+	if m != nil {
+		return m.TotalKwh
+	}
+	return 0
+}
+
+func (m *PowerReading) GetOnTodaySeconds() int64 {
+	if m != nil {
+		return m.OnTodaySeconds
+	}
+	return 0
+}
+
+func (m *PowerReading) GetStandbyThresholdMw() int64 {
+	if m != nil {
+		return m.StandbyThresholdMw
+	}
+	return 0
+}
+
 type ListDevicesRequest struct {
 }
 
 func (m *ListDevicesRequest) Reset()                    { *m = ListDevicesRequest{} }
 func (m *ListDevicesRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListDevicesRequest) ProtoMessage()               {}
-func (*ListDevicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 type ListDevicesResponse struct {
 	Device []*Device `protobuf:"bytes,1,rep,name=device" json:"device,omitempty"`
@@ -84,7 +468,7 @@ type ListDevicesResponse struct {
 func (m *ListDevicesResponse) Reset()                    { *m = ListDevicesResponse{} }
 func (m *ListDevicesResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListDevicesResponse) ProtoMessage()               {}
-func (*ListDevicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *ListDevicesResponse) GetDevice() []*Device {
 	if m != nil {
@@ -100,7 +484,7 @@ type GetDeviceRequest struct {
 func (m *GetDeviceRequest) Reset()                    { *m = GetDeviceRequest{} }
 func (m *GetDeviceRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetDeviceRequest) ProtoMessage()               {}
-func (*GetDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*GetDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *GetDeviceRequest) GetName() string {
 	if m != nil {
@@ -111,12 +495,15 @@ func (m *GetDeviceRequest) GetName() string {
 
 type UpdateDeviceRequest struct {
 	Device *Device `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+	// Switch a WeMo Maker to the momentary mode of device, before setting its
+	// state. The mode is left alone otherwise.
+	UpdateMomentary bool `protobuf:"varint,2,opt,name=update_momentary,json=updateMomentary" json:"update_momentary,omitempty"`
 }
 
 func (m *UpdateDeviceRequest) Reset()                    { *m = UpdateDeviceRequest{} }
 func (m *UpdateDeviceRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateDeviceRequest) ProtoMessage()               {}
-func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *UpdateDeviceRequest) GetDevice() *Device {
 	if m != nil {
@@ -125,127 +512,2513 @@ func (m *UpdateDeviceRequest) GetDevice() *Device {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Device)(nil), "apartment.Device")
-	proto.RegisterType((*ListDevicesRequest)(nil), "apartment.ListDevicesRequest")
-	proto.RegisterType((*ListDevicesResponse)(nil), "apartment.ListDevicesResponse")
-	proto.RegisterType((*GetDeviceRequest)(nil), "apartment.GetDeviceRequest")
-	proto.RegisterType((*UpdateDeviceRequest)(nil), "apartment.UpdateDeviceRequest")
+func (m *UpdateDeviceRequest) GetUpdateMomentary() bool {
+	if m != nil {
+		return m.UpdateMomentary
+	}
+	return false
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type WatchDevicesRequest struct {
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *WatchDevicesRequest) Reset()                    { *m = WatchDevicesRequest{} }
+func (m *WatchDevicesRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchDevicesRequest) ProtoMessage()               {}
+func (*WatchDevicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
-// Client API for Apartment service
+type DeviceEvent_Type int32
 
-type ApartmentClient interface {
-	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
-	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error)
-	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+const (
+	DeviceEvent_UNKNOWN       DeviceEvent_Type = 0
+	DeviceEvent_DISCOVERED    DeviceEvent_Type = 1
+	DeviceEvent_REMOVED       DeviceEvent_Type = 2
+	DeviceEvent_STATE_CHANGED DeviceEvent_Type = 3
+	DeviceEvent_RENAMED       DeviceEvent_Type = 4
+)
+
+var DeviceEvent_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "DISCOVERED",
+	2: "REMOVED",
+	3: "STATE_CHANGED",
+	4: "RENAMED",
+}
+var DeviceEvent_Type_value = map[string]int32{
+	"UNKNOWN":       0,
+	"DISCOVERED":    1,
+	"REMOVED":       2,
+	"STATE_CHANGED": 3,
+	"RENAMED":       4,
 }
 
-type apartmentClient struct {
-	cc *grpc.ClientConn
+func (x DeviceEvent_Type) String() string {
+	return proto.EnumName(DeviceEvent_Type_name, int32(x))
 }
+func (DeviceEvent_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8, 0} }
 
-func NewApartmentClient(cc *grpc.ClientConn) ApartmentClient {
-	return &apartmentClient{cc}
+type DeviceEvent struct {
+	Type   DeviceEvent_Type `protobuf:"varint,1,opt,name=type,enum=apartment.DeviceEvent.Type" json:"type,omitempty"`
+	Device *Device          `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
 }
 
-func (c *apartmentClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
-	out := new(ListDevicesResponse)
-	err := grpc.Invoke(ctx, "/apartment.Apartment/ListDevices", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeviceEvent) Reset()                    { *m = DeviceEvent{} }
+func (m *DeviceEvent) String() string            { return proto.CompactTextString(m) }
+func (*DeviceEvent) ProtoMessage()               {}
+func (*DeviceEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *DeviceEvent) GetType() DeviceEvent_Type {
+	if m != nil {
+		return m.Type
 	}
-	return out, nil
+	return DeviceEvent_UNKNOWN
 }
 
-func (c *apartmentClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
-	out := new(Device)
-	err := grpc.Invoke(ctx, "/apartment.Apartment/GetDevice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DeviceEvent) GetDevice() *Device {
+	if m != nil {
+		return m.Device
 	}
-	return out, nil
+	return nil
 }
 
-func (c *apartmentClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
-	out := new(Device)
-	err := grpc.Invoke(ctx, "/apartment.Apartment/UpdateDevice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type RenameDeviceRequest struct {
+	// Current name of the device.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// New friendly name, stored on the device itself.
+	FriendlyName string `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName" json:"friendly_name,omitempty"`
 }
 
-// Server API for Apartment service
+func (m *RenameDeviceRequest) Reset()                    { *m = RenameDeviceRequest{} }
+func (m *RenameDeviceRequest) String() string            { return proto.CompactTextString(m) }
+func (*RenameDeviceRequest) ProtoMessage()               {}
+func (*RenameDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
-type ApartmentServer interface {
-	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
-	GetDevice(context.Context, *GetDeviceRequest) (*Device, error)
-	UpdateDevice(context.Context, *UpdateDeviceRequest) (*Device, error)
+func (m *RenameDeviceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func RegisterApartmentServer(s *grpc.Server, srv ApartmentServer) {
-	s.RegisterService(&_Apartment_serviceDesc, srv)
+func (m *RenameDeviceRequest) GetFriendlyName() string {
+	if m != nil {
+		return m.FriendlyName
+	}
+	return ""
 }
 
-func _Apartment_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDevicesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ApartmentServer).ListDevices(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/apartment.Apartment/ListDevices",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApartmentServer).ListDevices(ctx, req.(*ListDevicesRequest))
+type SetDeviceNamesRequest struct {
+	// Current name, alias or UDN of the device.
+	Name        string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName" json:"display_name,omitempty"`
+	// Replaces all existing aliases of the device.
+	Aliases []string `protobuf:"bytes,3,rep,name=aliases" json:"aliases,omitempty"`
+}
+
+func (m *SetDeviceNamesRequest) Reset()                    { *m = SetDeviceNamesRequest{} }
+func (m *SetDeviceNamesRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetDeviceNamesRequest) ProtoMessage()               {}
+func (*SetDeviceNamesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *SetDeviceNamesRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Apartment_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *SetDeviceNamesRequest) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
 	}
-	if interceptor == nil {
-		return srv.(ApartmentServer).GetDevice(ctx, in)
+	return ""
+}
+
+func (m *SetDeviceNamesRequest) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
+	return nil
+}
+
+// Group is a named set of devices, such as a room.
+type Group struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Devices may be given by name, alias or UDN. They are returned as UDNs.
+	Devices []string `protobuf:"bytes,2,rep,name=devices" json:"devices,omitempty"`
+}
+
+func (m *Group) Reset()                    { *m = Group{} }
+func (m *Group) String() string            { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()               {}
+func (*Group) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *Group) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Group) GetDevices() []string {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type CreateGroupRequest struct {
+	Group *Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+}
+
+func (m *CreateGroupRequest) Reset()                    { *m = CreateGroupRequest{} }
+func (m *CreateGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateGroupRequest) ProtoMessage()               {}
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *CreateGroupRequest) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+type ListGroupsRequest struct {
+}
+
+func (m *ListGroupsRequest) Reset()                    { *m = ListGroupsRequest{} }
+func (m *ListGroupsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListGroupsRequest) ProtoMessage()               {}
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+type ListGroupsResponse struct {
+	Group []*Group `protobuf:"bytes,1,rep,name=group" json:"group,omitempty"`
+}
+
+func (m *ListGroupsResponse) Reset()                    { *m = ListGroupsResponse{} }
+func (m *ListGroupsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListGroupsResponse) ProtoMessage()               {}
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *ListGroupsResponse) GetGroup() []*Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+type UpdateGroupRequest struct {
+	Group *Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+}
+
+func (m *UpdateGroupRequest) Reset()                    { *m = UpdateGroupRequest{} }
+func (m *UpdateGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateGroupRequest) ProtoMessage()               {}
+func (*UpdateGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *UpdateGroupRequest) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+type DeleteGroupRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteGroupRequest) Reset()                    { *m = DeleteGroupRequest{} }
+func (m *DeleteGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteGroupRequest) ProtoMessage()               {}
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *DeleteGroupRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteGroupResponse struct {
+}
+
+func (m *DeleteGroupResponse) Reset()                    { *m = DeleteGroupResponse{} }
+func (m *DeleteGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteGroupResponse) ProtoMessage()               {}
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+type SetGroupStateRequest struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	State bool   `protobuf:"varint,2,opt,name=state" json:"state,omitempty"`
+}
+
+func (m *SetGroupStateRequest) Reset()                    { *m = SetGroupStateRequest{} }
+func (m *SetGroupStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetGroupStateRequest) ProtoMessage()               {}
+func (*SetGroupStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *SetGroupStateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetGroupStateRequest) GetState() bool {
+	if m != nil {
+		return m.State
+	}
+	return false
+}
+
+type SetGroupStateResponse struct {
+	Result []*DeviceResult `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *SetGroupStateResponse) Reset()                    { *m = SetGroupStateResponse{} }
+func (m *SetGroupStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetGroupStateResponse) ProtoMessage()               {}
+func (*SetGroupStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *SetGroupStateResponse) GetResult() []*DeviceResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// DeviceResult reports the outcome of changing one of many devices.
+type DeviceResult struct {
+	Udn     string `protobuf:"bytes,1,opt,name=udn" json:"udn,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Success bool   `protobuf:"varint,3,opt,name=success" json:"success,omitempty"`
+	Error   string `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	// The device after the change, set on success.
+	Device *Device `protobuf:"bytes,5,opt,name=device" json:"device,omitempty"`
+}
+
+func (m *DeviceResult) Reset()                    { *m = DeviceResult{} }
+func (m *DeviceResult) String() string            { return proto.CompactTextString(m) }
+func (*DeviceResult) ProtoMessage()               {}
+func (*DeviceResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *DeviceResult) GetUdn() string {
+	if m != nil {
+		return m.Udn
+	}
+	return ""
+}
+
+func (m *DeviceResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeviceResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *DeviceResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeviceResult) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+// Scene is a named snapshot of device states.
+type Scene struct {
+	Name    string         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Devices []*SceneDevice `protobuf:"bytes,2,rep,name=devices" json:"devices,omitempty"`
+}
+
+func (m *Scene) Reset()                    { *m = Scene{} }
+func (m *Scene) String() string            { return proto.CompactTextString(m) }
+func (*Scene) ProtoMessage()               {}
+func (*Scene) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *Scene) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Scene) GetDevices() []*SceneDevice {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type SceneDevice struct {
+	Udn   string `protobuf:"bytes,1,opt,name=udn" json:"udn,omitempty"`
+	State bool   `protobuf:"varint,2,opt,name=state" json:"state,omitempty"`
+	// Brightness of dimmable devices which are on, 0 otherwise.
+	Brightness int32 `protobuf:"varint,3,opt,name=brightness" json:"brightness,omitempty"`
+}
+
+func (m *SceneDevice) Reset()                    { *m = SceneDevice{} }
+func (m *SceneDevice) String() string            { return proto.CompactTextString(m) }
+func (*SceneDevice) ProtoMessage()               {}
+func (*SceneDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *SceneDevice) GetUdn() string {
+	if m != nil {
+		return m.Udn
+	}
+	return ""
+}
+
+func (m *SceneDevice) GetState() bool {
+	if m != nil {
+		return m.State
+	}
+	return false
+}
+
+func (m *SceneDevice) GetBrightness() int32 {
+	if m != nil {
+		return m.Brightness
+	}
+	return 0
+}
+
+type CreateSceneRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Devices to capture, by name, alias or UDN.
+	Devices []string `protobuf:"bytes,2,rep,name=devices" json:"devices,omitempty"`
+}
+
+func (m *CreateSceneRequest) Reset()                    { *m = CreateSceneRequest{} }
+func (m *CreateSceneRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateSceneRequest) ProtoMessage()               {}
+func (*CreateSceneRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *CreateSceneRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateSceneRequest) GetDevices() []string {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type ListScenesRequest struct {
+}
+
+func (m *ListScenesRequest) Reset()                    { *m = ListScenesRequest{} }
+func (m *ListScenesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListScenesRequest) ProtoMessage()               {}
+func (*ListScenesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+type ListScenesResponse struct {
+	Scene []*Scene `protobuf:"bytes,1,rep,name=scene" json:"scene,omitempty"`
+}
+
+func (m *ListScenesResponse) Reset()                    { *m = ListScenesResponse{} }
+func (m *ListScenesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListScenesResponse) ProtoMessage()               {}
+func (*ListScenesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ListScenesResponse) GetScene() []*Scene {
+	if m != nil {
+		return m.Scene
+	}
+	return nil
+}
+
+type DeleteSceneRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteSceneRequest) Reset()                    { *m = DeleteSceneRequest{} }
+func (m *DeleteSceneRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSceneRequest) ProtoMessage()               {}
+func (*DeleteSceneRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *DeleteSceneRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteSceneResponse struct {
+}
+
+func (m *DeleteSceneResponse) Reset()                    { *m = DeleteSceneResponse{} }
+func (m *DeleteSceneResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteSceneResponse) ProtoMessage()               {}
+func (*DeleteSceneResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+type ActivateSceneRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ActivateSceneRequest) Reset()                    { *m = ActivateSceneRequest{} }
+func (m *ActivateSceneRequest) String() string            { return proto.CompactTextString(m) }
+func (*ActivateSceneRequest) ProtoMessage()               {}
+func (*ActivateSceneRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ActivateSceneRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ActivateSceneResponse struct {
+	Result []*DeviceResult `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ActivateSceneResponse) Reset()                    { *m = ActivateSceneResponse{} }
+func (m *ActivateSceneResponse) String() string            { return proto.CompactTextString(m) }
+func (*ActivateSceneResponse) ProtoMessage()               {}
+func (*ActivateSceneResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ActivateSceneResponse) GetResult() []*DeviceResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// Action is something automation can do. Exactly one of device, group,
+// scene, delay_seconds or notify must be set.
+type Action struct {
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+	Group  string `protobuf:"bytes,2,opt,name=group" json:"group,omitempty"`
+	Scene  string `protobuf:"bytes,3,opt,name=scene" json:"scene,omitempty"`
+	// State to set the device or group to. Unused for scenes.
+	State bool `protobuf:"varint,4,opt,name=state" json:"state,omitempty"`
+	// Brightness to set a dimmable device to when turning it on.
+	Brightness int32 `protobuf:"varint,5,opt,name=brightness" json:"brightness,omitempty"`
+	// Wait before running the next action of a rule.
+	DelaySeconds int64 `protobuf:"varint,6,opt,name=delay_seconds,json=delaySeconds" json:"delay_seconds,omitempty"`
+	// Message to send to the server's notification URL.
+	Notify string `protobuf:"bytes,7,opt,name=notify" json:"notify,omitempty"`
+}
+
+func (m *Action) Reset()                    { *m = Action{} }
+func (m *Action) String() string            { return proto.CompactTextString(m) }
+func (*Action) ProtoMessage()               {}
+func (*Action) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Action) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *Action) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *Action) GetScene() string {
+	if m != nil {
+		return m.Scene
+	}
+	return ""
+}
+
+func (m *Action) GetState() bool {
+	if m != nil {
+		return m.State
+	}
+	return false
+}
+
+func (m *Action) GetBrightness() int32 {
+	if m != nil {
+		return m.Brightness
+	}
+	return 0
+}
+
+func (m *Action) GetDelaySeconds() int64 {
+	if m != nil {
+		return m.DelaySeconds
+	}
+	return 0
+}
+
+func (m *Action) GetNotify() string {
+	if m != nil {
+		return m.Notify
+	}
+	return ""
+}
+
+// CatchUp decides what happens to runs missed while the server was down.
+type Schedule_CatchUp int32
+
+const (
+	Schedule_SKIP Schedule_CatchUp = 0
+	// Run the action once if any runs were missed.
+	Schedule_RUN_ONCE Schedule_CatchUp = 1
+)
+
+var Schedule_CatchUp_name = map[int32]string{
+	0: "SKIP",
+	1: "RUN_ONCE",
+}
+var Schedule_CatchUp_value = map[string]int32{
+	"SKIP":     0,
+	"RUN_ONCE": 1,
+}
+
+func (x Schedule_CatchUp) String() string {
+	return proto.EnumName(Schedule_CatchUp_name, int32(x))
+}
+func (Schedule_CatchUp) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{31, 0} }
+
+// Schedule runs an action at times given by a cron-style spec.
+type Schedule struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Minute, hour, day of month, month and day of week, e.g. "0 7 * * mon-fri".
+	// Alternatively an offset from sunrise or sunset, optionally limited to
+	// some days of the week, e.g. "@sunset -30m" or "@sunrise +15m mon-fri".
+	// Times are in the server's configured timezone.
+	Spec    string           `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+	Action  *Action          `protobuf:"bytes,3,opt,name=action" json:"action,omitempty"`
+	CatchUp Schedule_CatchUp `protobuf:"varint,4,opt,name=catch_up,json=catchUp,enum=apartment.Schedule.CatchUp" json:"catch_up,omitempty"`
+	// Unix timestamps, set in responses.
+	LastRun int64 `protobuf:"varint,5,opt,name=last_run,json=lastRun" json:"last_run,omitempty"`
+	NextRun int64 `protobuf:"varint,6,opt,name=next_run,json=nextRun" json:"next_run,omitempty"`
+}
+
+func (m *Schedule) Reset()                    { *m = Schedule{} }
+func (m *Schedule) String() string            { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()               {}
+func (*Schedule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *Schedule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Schedule) GetSpec() string {
+	if m != nil {
+		return m.Spec
+	}
+	return ""
+}
+
+func (m *Schedule) GetAction() *Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *Schedule) GetCatchUp() Schedule_CatchUp {
+	if m != nil {
+		return m.CatchUp
+	}
+	return Schedule_SKIP
+}
+
+func (m *Schedule) GetLastRun() int64 {
+	if m != nil {
+		return m.LastRun
+	}
+	return 0
+}
+
+func (m *Schedule) GetNextRun() int64 {
+	if m != nil {
+		return m.NextRun
+	}
+	return 0
+}
+
+type CreateScheduleRequest struct {
+	Schedule *Schedule `protobuf:"bytes,1,opt,name=schedule" json:"schedule,omitempty"`
+}
+
+func (m *CreateScheduleRequest) Reset()                    { *m = CreateScheduleRequest{} }
+func (m *CreateScheduleRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateScheduleRequest) ProtoMessage()               {}
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *CreateScheduleRequest) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+}
+
+func (m *ListSchedulesRequest) Reset()                    { *m = ListSchedulesRequest{} }
+func (m *ListSchedulesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSchedulesRequest) ProtoMessage()               {}
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type ListSchedulesResponse struct {
+	Schedule []*Schedule `protobuf:"bytes,1,rep,name=schedule" json:"schedule,omitempty"`
+}
+
+func (m *ListSchedulesResponse) Reset()                    { *m = ListSchedulesResponse{} }
+func (m *ListSchedulesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSchedulesResponse) ProtoMessage()               {}
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *ListSchedulesResponse) GetSchedule() []*Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type DeleteScheduleRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteScheduleRequest) Reset()                    { *m = DeleteScheduleRequest{} }
+func (m *DeleteScheduleRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteScheduleRequest) ProtoMessage()               {}
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *DeleteScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteScheduleResponse struct {
+}
+
+func (m *DeleteScheduleResponse) Reset()                    { *m = DeleteScheduleResponse{} }
+func (m *DeleteScheduleResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteScheduleResponse) ProtoMessage()               {}
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+type GetSolarTimesRequest struct {
+}
+
+func (m *GetSolarTimesRequest) Reset()                    { *m = GetSolarTimesRequest{} }
+func (m *GetSolarTimesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetSolarTimesRequest) ProtoMessage()               {}
+func (*GetSolarTimesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+// SolarTimes are the next solar events at the server's location.
+type SolarTimes struct {
+	// Unix timestamps.
+	NextSunrise int64   `protobuf:"varint,1,opt,name=next_sunrise,json=nextSunrise" json:"next_sunrise,omitempty"`
+	NextSunset  int64   `protobuf:"varint,2,opt,name=next_sunset,json=nextSunset" json:"next_sunset,omitempty"`
+	Latitude    float64 `protobuf:"fixed64,3,opt,name=latitude" json:"latitude,omitempty"`
+	Longitude   float64 `protobuf:"fixed64,4,opt,name=longitude" json:"longitude,omitempty"`
+	Timezone    string  `protobuf:"bytes,5,opt,name=timezone" json:"timezone,omitempty"`
+}
+
+func (m *SolarTimes) Reset()                    { *m = SolarTimes{} }
+func (m *SolarTimes) String() string            { return proto.CompactTextString(m) }
+func (*SolarTimes) ProtoMessage()               {}
+func (*SolarTimes) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *SolarTimes) GetNextSunrise() int64 {
+	if m != nil {
+		return m.NextSunrise
+	}
+	return 0
+}
+
+func (m *SolarTimes) GetNextSunset() int64 {
+	if m != nil {
+		return m.NextSunset
+	}
+	return 0
+}
+
+func (m *SolarTimes) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *SolarTimes) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *SolarTimes) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+// Rule runs its actions, in order, when its trigger fires and all of its
+// conditions hold.
+type Rule struct {
+	Name       string       `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Disabled   bool         `protobuf:"varint,2,opt,name=disabled" json:"disabled,omitempty"`
+	Trigger    *Trigger     `protobuf:"bytes,3,opt,name=trigger" json:"trigger,omitempty"`
+	Conditions []*Condition `protobuf:"bytes,4,rep,name=conditions" json:"conditions,omitempty"`
+	Actions    []*Action    `protobuf:"bytes,5,rep,name=actions" json:"actions,omitempty"`
+	// Unix timestamp, set in responses.
+	LastFired int64 `protobuf:"varint,6,opt,name=last_fired,json=lastFired" json:"last_fired,omitempty"`
+}
+
+func (m *Rule) Reset()                    { *m = Rule{} }
+func (m *Rule) String() string            { return proto.CompactTextString(m) }
+func (*Rule) ProtoMessage()               {}
+func (*Rule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *Rule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Rule) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
+func (m *Rule) GetTrigger() *Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return nil
+}
+
+func (m *Rule) GetConditions() []*Condition {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+func (m *Rule) GetActions() []*Action {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *Rule) GetLastFired() int64 {
+	if m != nil {
+		return m.LastFired
+	}
+	return 0
+}
+
+type Trigger_Type int32
+
+const (
+	// The device was switched to state.
+	Trigger_DEVICE_STATE Trigger_Type = 0
+	// The device's sensor changed to state, true meaning motion or a
+	// triggered Maker input.
+	Trigger_SENSOR Trigger_Type = 1
+	// The time matches spec, in the format of Schedule.spec.
+	Trigger_TIME Trigger_Type = 2
+	// The power draw of an Insight rose above threshold_mw.
+	Trigger_POWER_ABOVE Trigger_Type = 3
+	// The power draw of an Insight fell below threshold_mw.
+	Trigger_POWER_BELOW Trigger_Type = 4
+)
+
+var Trigger_Type_name = map[int32]string{
+	0: "DEVICE_STATE",
+	1: "SENSOR",
+	2: "TIME",
+	3: "POWER_ABOVE",
+	4: "POWER_BELOW",
+}
+var Trigger_Type_value = map[string]int32{
+	"DEVICE_STATE": 0,
+	"SENSOR":       1,
+	"TIME":         2,
+	"POWER_ABOVE":  3,
+	"POWER_BELOW":  4,
+}
+
+func (x Trigger_Type) String() string {
+	return proto.EnumName(Trigger_Type_name, int32(x))
+}
+func (Trigger_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{40, 0} }
+
+type Trigger struct {
+	Type Trigger_Type `protobuf:"varint,1,opt,name=type,enum=apartment.Trigger.Type" json:"type,omitempty"`
+	// Device by name, alias or UDN. Returned as a UDN.
+	Device      string `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
+	State       bool   `protobuf:"varint,3,opt,name=state" json:"state,omitempty"`
+	Spec        string `protobuf:"bytes,4,opt,name=spec" json:"spec,omitempty"`
+	ThresholdMw int64  `protobuf:"varint,5,opt,name=threshold_mw,json=thresholdMw" json:"threshold_mw,omitempty"`
+}
+
+func (m *Trigger) Reset()                    { *m = Trigger{} }
+func (m *Trigger) String() string            { return proto.CompactTextString(m) }
+func (*Trigger) ProtoMessage()               {}
+func (*Trigger) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *Trigger) GetType() Trigger_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Trigger_DEVICE_STATE
+}
+
+func (m *Trigger) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *Trigger) GetState() bool {
+	if m != nil {
+		return m.State
+	}
+	return false
+}
+
+func (m *Trigger) GetSpec() string {
+	if m != nil {
+		return m.Spec
+	}
+	return ""
+}
+
+func (m *Trigger) GetThresholdMw() int64 {
+	if m != nil {
+		return m.ThresholdMw
+	}
+	return 0
+}
+
+// Condition is either a daily time window or the state of a device.
+type Condition struct {
+	// Window in the server's timezone as HH:MM. It may wrap past midnight.
+	After  string `protobuf:"bytes,1,opt,name=after" json:"after,omitempty"`
+	Before string `protobuf:"bytes,2,opt,name=before" json:"before,omitempty"`
+	// Device by name, alias or UDN, which must be in state.
+	Device string `protobuf:"bytes,3,opt,name=device" json:"device,omitempty"`
+	State  bool   `protobuf:"varint,4,opt,name=state" json:"state,omitempty"`
+}
+
+func (m *Condition) Reset()                    { *m = Condition{} }
+func (m *Condition) String() string            { return proto.CompactTextString(m) }
+func (*Condition) ProtoMessage()               {}
+func (*Condition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *Condition) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *Condition) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *Condition) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *Condition) GetState() bool {
+	if m != nil {
+		return m.State
+	}
+	return false
+}
+
+type CreateRuleRequest struct {
+	Rule *Rule `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+}
+
+func (m *CreateRuleRequest) Reset()                    { *m = CreateRuleRequest{} }
+func (m *CreateRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateRuleRequest) ProtoMessage()               {}
+func (*CreateRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *CreateRuleRequest) GetRule() *Rule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type GetRuleRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *GetRuleRequest) Reset()                    { *m = GetRuleRequest{} }
+func (m *GetRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRuleRequest) ProtoMessage()               {}
+func (*GetRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *GetRuleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListRulesRequest struct {
+}
+
+func (m *ListRulesRequest) Reset()                    { *m = ListRulesRequest{} }
+func (m *ListRulesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRulesRequest) ProtoMessage()               {}
+func (*ListRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+type ListRulesResponse struct {
+	Rule []*Rule `protobuf:"bytes,1,rep,name=rule" json:"rule,omitempty"`
+}
+
+func (m *ListRulesResponse) Reset()                    { *m = ListRulesResponse{} }
+func (m *ListRulesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListRulesResponse) ProtoMessage()               {}
+func (*ListRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ListRulesResponse) GetRule() []*Rule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type UpdateRuleRequest struct {
+	Rule *Rule `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+}
+
+func (m *UpdateRuleRequest) Reset()                    { *m = UpdateRuleRequest{} }
+func (m *UpdateRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateRuleRequest) ProtoMessage()               {}
+func (*UpdateRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *UpdateRuleRequest) GetRule() *Rule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type DeleteRuleRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteRuleRequest) Reset()                    { *m = DeleteRuleRequest{} }
+func (m *DeleteRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRuleRequest) ProtoMessage()               {}
+func (*DeleteRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *DeleteRuleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteRuleResponse struct {
+}
+
+func (m *DeleteRuleResponse) Reset()                    { *m = DeleteRuleResponse{} }
+func (m *DeleteRuleResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRuleResponse) ProtoMessage()               {}
+func (*DeleteRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+type ListScriptsRequest struct {
+}
+
+func (m *ListScriptsRequest) Reset()                    { *m = ListScriptsRequest{} }
+func (m *ListScriptsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListScriptsRequest) ProtoMessage()               {}
+func (*ListScriptsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+type ListScriptsResponse struct {
+	Script []*Script `protobuf:"bytes,1,rep,name=script" json:"script,omitempty"`
+}
+
+func (m *ListScriptsResponse) Reset()                    { *m = ListScriptsResponse{} }
+func (m *ListScriptsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListScriptsResponse) ProtoMessage()               {}
+func (*ListScriptsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *ListScriptsResponse) GetScript() []*Script {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+// Script is a Starlark automation script loaded by the server.
+type Script struct {
+	// File name within the scripts directory.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Unix timestamp of when the current version was loaded.
+	Loaded int64 `protobuf:"varint,2,opt,name=loaded" json:"loaded,omitempty"`
+	// Last error, from loading the script or running one of its callbacks.
+	Error     string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	ErrorTime int64  `protobuf:"varint,4,opt,name=error_time,json=errorTime" json:"error_time,omitempty"`
+}
+
+func (m *Script) Reset()                    { *m = Script{} }
+func (m *Script) String() string            { return proto.CompactTextString(m) }
+func (*Script) ProtoMessage()               {}
+func (*Script) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *Script) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Script) GetLoaded() int64 {
+	if m != nil {
+		return m.Loaded
+	}
+	return 0
+}
+
+func (m *Script) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Script) GetErrorTime() int64 {
+	if m != nil {
+		return m.ErrorTime
+	}
+	return 0
+}
+
+type SetDeviceStateForRequest struct {
+	// The device to update, as in UpdateDeviceRequest.
+	Device *Device `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+	// After this long the device is set back to its previous state.
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds" json:"duration_seconds,omitempty"`
+}
+
+func (m *SetDeviceStateForRequest) Reset()                    { *m = SetDeviceStateForRequest{} }
+func (m *SetDeviceStateForRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetDeviceStateForRequest) ProtoMessage()               {}
+func (*SetDeviceStateForRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *SetDeviceStateForRequest) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *SetDeviceStateForRequest) GetDurationSeconds() int64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+// Timer reverts a device to its previous state when it expires.
+type Timer struct {
+	Udn         string `protobuf:"bytes,1,opt,name=udn" json:"udn,omitempty"`
+	RevertState bool   `protobuf:"varint,2,opt,name=revert_state,json=revertState" json:"revert_state,omitempty"`
+	// Unix timestamp.
+	Expires int64 `protobuf:"varint,3,opt,name=expires" json:"expires,omitempty"`
+}
+
+func (m *Timer) Reset()                    { *m = Timer{} }
+func (m *Timer) String() string            { return proto.CompactTextString(m) }
+func (*Timer) ProtoMessage()               {}
+func (*Timer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *Timer) GetUdn() string {
+	if m != nil {
+		return m.Udn
+	}
+	return ""
+}
+
+func (m *Timer) GetRevertState() bool {
+	if m != nil {
+		return m.RevertState
+	}
+	return false
+}
+
+func (m *Timer) GetExpires() int64 {
+	if m != nil {
+		return m.Expires
+	}
+	return 0
+}
+
+type ListTimersRequest struct {
+}
+
+func (m *ListTimersRequest) Reset()                    { *m = ListTimersRequest{} }
+func (m *ListTimersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListTimersRequest) ProtoMessage()               {}
+func (*ListTimersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+type ListTimersResponse struct {
+	Timer []*Timer `protobuf:"bytes,1,rep,name=timer" json:"timer,omitempty"`
+}
+
+func (m *ListTimersResponse) Reset()                    { *m = ListTimersResponse{} }
+func (m *ListTimersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListTimersResponse) ProtoMessage()               {}
+func (*ListTimersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *ListTimersResponse) GetTimer() []*Timer {
+	if m != nil {
+		return m.Timer
+	}
+	return nil
+}
+
+type CancelTimerRequest struct {
+	// Device name, alias or UDN.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *CancelTimerRequest) Reset()                    { *m = CancelTimerRequest{} }
+func (m *CancelTimerRequest) String() string            { return proto.CompactTextString(m) }
+func (*CancelTimerRequest) ProtoMessage()               {}
+func (*CancelTimerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *CancelTimerRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CancelTimerResponse struct {
+}
+
+func (m *CancelTimerResponse) Reset()                    { *m = CancelTimerResponse{} }
+func (m *CancelTimerResponse) String() string            { return proto.CompactTextString(m) }
+func (*CancelTimerResponse) ProtoMessage()               {}
+func (*CancelTimerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+// Source is what caused a change.
+type HistoryRecord_Source int32
+
+const (
+	HistoryRecord_UNKNOWN HistoryRecord_Source = 0
+	// A client of the API. The detail is the client's address.
+	HistoryRecord_API HistoryRecord_Source = 1
+	// The detail is the name of the schedule, rule or script.
+	HistoryRecord_SCHEDULE HistoryRecord_Source = 2
+	HistoryRecord_RULE     HistoryRecord_Source = 3
+	HistoryRecord_SCRIPT   HistoryRecord_Source = 4
+	// A timer set by SetDeviceStateFor expired.
+	HistoryRecord_TIMER HistoryRecord_Source = 5
+	// The device reported the change itself, e.g. its button was pressed.
+	HistoryRecord_EVENT HistoryRecord_Source = 6
+	// The server noticed the change when polling the device.
+	HistoryRecord_DISCOVERY HistoryRecord_Source = 7
+)
+
+var HistoryRecord_Source_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "API",
+	2: "SCHEDULE",
+	3: "RULE",
+	4: "SCRIPT",
+	5: "TIMER",
+	6: "EVENT",
+	7: "DISCOVERY",
+}
+var HistoryRecord_Source_value = map[string]int32{
+	"UNKNOWN":   0,
+	"API":       1,
+	"SCHEDULE":  2,
+	"RULE":      3,
+	"SCRIPT":    4,
+	"TIMER":     5,
+	"EVENT":     6,
+	"DISCOVERY": 7,
+}
+
+func (x HistoryRecord_Source) String() string {
+	return proto.EnumName(HistoryRecord_Source_name, int32(x))
+}
+func (HistoryRecord_Source) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{58, 0} }
+
+// HistoryRecord is a recorded change of a device's state.
+type HistoryRecord struct {
+	// Unix timestamp.
+	Time     int64                `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	Udn      string               `protobuf:"bytes,2,opt,name=udn" json:"udn,omitempty"`
+	Name     string               `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	OldState PowerState           `protobuf:"varint,4,opt,name=old_state,json=oldState,enum=apartment.PowerState" json:"old_state,omitempty"`
+	NewState PowerState           `protobuf:"varint,5,opt,name=new_state,json=newState,enum=apartment.PowerState" json:"new_state,omitempty"`
+	Source   HistoryRecord_Source `protobuf:"varint,6,opt,name=source,enum=apartment.HistoryRecord.Source" json:"source,omitempty"`
+	Detail   string               `protobuf:"bytes,7,opt,name=detail" json:"detail,omitempty"`
+}
+
+func (m *HistoryRecord) Reset()                    { *m = HistoryRecord{} }
+func (m *HistoryRecord) String() string            { return proto.CompactTextString(m) }
+func (*HistoryRecord) ProtoMessage()               {}
+func (*HistoryRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *HistoryRecord) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *HistoryRecord) GetUdn() string {
+	if m != nil {
+		return m.Udn
+	}
+	return ""
+}
+
+func (m *HistoryRecord) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HistoryRecord) GetOldState() PowerState {
+	if m != nil {
+		return m.OldState
+	}
+	return PowerState_UNKNOWN
+}
+
+func (m *HistoryRecord) GetNewState() PowerState {
+	if m != nil {
+		return m.NewState
+	}
+	return PowerState_UNKNOWN
+}
+
+func (m *HistoryRecord) GetSource() HistoryRecord_Source {
+	if m != nil {
+		return m.Source
+	}
+	return HistoryRecord_UNKNOWN
+}
+
+func (m *HistoryRecord) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type QueryHistoryRequest struct {
+	// Device name, alias or UDN. All devices if unset.
+	Device string `protobuf:"bytes,1,opt,name=device" json:"device,omitempty"`
+	// Unix timestamps bounding the records, start inclusive, end exclusive.
+	StartTime int64 `protobuf:"varint,2,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	EndTime   int64 `protobuf:"varint,3,opt,name=end_time,json=endTime" json:"end_time,omitempty"`
+	// Sources to include. All sources if unset.
+	Sources []HistoryRecord_Source `protobuf:"varint,4,rep,packed,name=sources,enum=apartment.HistoryRecord.Source" json:"sources,omitempty"`
+	// At most 100 records are returned per page by default, up to 1000.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	// next_page_token of the previous page.
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken" json:"page_token,omitempty"`
+}
+
+func (m *QueryHistoryRequest) Reset()                    { *m = QueryHistoryRequest{} }
+func (m *QueryHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryHistoryRequest) ProtoMessage()               {}
+func (*QueryHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *QueryHistoryRequest) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *QueryHistoryRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *QueryHistoryRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *QueryHistoryRequest) GetSources() []HistoryRecord_Source {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *QueryHistoryRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *QueryHistoryRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type QueryHistoryResponse struct {
+	// Newest first.
+	Record []*HistoryRecord `protobuf:"bytes,1,rep,name=record" json:"record,omitempty"`
+	// Set if there are more records.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken" json:"next_page_token,omitempty"`
+}
+
+func (m *QueryHistoryResponse) Reset()                    { *m = QueryHistoryResponse{} }
+func (m *QueryHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryHistoryResponse) ProtoMessage()               {}
+func (*QueryHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *QueryHistoryResponse) GetRecord() []*HistoryRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (m *QueryHistoryResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type AddDeviceRequest struct {
+	// Address of the device, e.g. 192.168.1.20:49153.
+	Host string `protobuf:"bytes,1,opt,name=host" json:"host,omitempty"`
+}
+
+func (m *AddDeviceRequest) Reset()                    { *m = AddDeviceRequest{} }
+func (m *AddDeviceRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddDeviceRequest) ProtoMessage()               {}
+func (*AddDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *AddDeviceRequest) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+type AddDeviceResponse struct {
+	// The added device, or the bulbs of an added bridge.
+	Device []*Device `protobuf:"bytes,1,rep,name=device" json:"device,omitempty"`
+}
+
+func (m *AddDeviceResponse) Reset()                    { *m = AddDeviceResponse{} }
+func (m *AddDeviceResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddDeviceResponse) ProtoMessage()               {}
+func (*AddDeviceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *AddDeviceResponse) GetDevice() []*Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+type ForgetDeviceRequest struct {
+	// Device name, alias or UDN.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ForgetDeviceRequest) Reset()                    { *m = ForgetDeviceRequest{} }
+func (m *ForgetDeviceRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForgetDeviceRequest) ProtoMessage()               {}
+func (*ForgetDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *ForgetDeviceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ForgetDeviceResponse struct {
+}
+
+func (m *ForgetDeviceResponse) Reset()                    { *m = ForgetDeviceResponse{} }
+func (m *ForgetDeviceResponse) String() string            { return proto.CompactTextString(m) }
+func (*ForgetDeviceResponse) ProtoMessage()               {}
+func (*ForgetDeviceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func init() {
+	proto.RegisterType((*Device)(nil), "apartment.Device")
+	proto.RegisterType((*Sensor)(nil), "apartment.Sensor")
+	proto.RegisterType((*PowerReading)(nil), "apartment.PowerReading")
+	proto.RegisterType((*ListDevicesRequest)(nil), "apartment.ListDevicesRequest")
+	proto.RegisterType((*ListDevicesResponse)(nil), "apartment.ListDevicesResponse")
+	proto.RegisterType((*GetDeviceRequest)(nil), "apartment.GetDeviceRequest")
+	proto.RegisterType((*UpdateDeviceRequest)(nil), "apartment.UpdateDeviceRequest")
+	proto.RegisterType((*WatchDevicesRequest)(nil), "apartment.WatchDevicesRequest")
+	proto.RegisterType((*DeviceEvent)(nil), "apartment.DeviceEvent")
+	proto.RegisterType((*RenameDeviceRequest)(nil), "apartment.RenameDeviceRequest")
+	proto.RegisterType((*SetDeviceNamesRequest)(nil), "apartment.SetDeviceNamesRequest")
+	proto.RegisterType((*Group)(nil), "apartment.Group")
+	proto.RegisterType((*CreateGroupRequest)(nil), "apartment.CreateGroupRequest")
+	proto.RegisterType((*ListGroupsRequest)(nil), "apartment.ListGroupsRequest")
+	proto.RegisterType((*ListGroupsResponse)(nil), "apartment.ListGroupsResponse")
+	proto.RegisterType((*UpdateGroupRequest)(nil), "apartment.UpdateGroupRequest")
+	proto.RegisterType((*DeleteGroupRequest)(nil), "apartment.DeleteGroupRequest")
+	proto.RegisterType((*DeleteGroupResponse)(nil), "apartment.DeleteGroupResponse")
+	proto.RegisterType((*SetGroupStateRequest)(nil), "apartment.SetGroupStateRequest")
+	proto.RegisterType((*SetGroupStateResponse)(nil), "apartment.SetGroupStateResponse")
+	proto.RegisterType((*DeviceResult)(nil), "apartment.DeviceResult")
+	proto.RegisterType((*Scene)(nil), "apartment.Scene")
+	proto.RegisterType((*SceneDevice)(nil), "apartment.SceneDevice")
+	proto.RegisterType((*CreateSceneRequest)(nil), "apartment.CreateSceneRequest")
+	proto.RegisterType((*ListScenesRequest)(nil), "apartment.ListScenesRequest")
+	proto.RegisterType((*ListScenesResponse)(nil), "apartment.ListScenesResponse")
+	proto.RegisterType((*DeleteSceneRequest)(nil), "apartment.DeleteSceneRequest")
+	proto.RegisterType((*DeleteSceneResponse)(nil), "apartment.DeleteSceneResponse")
+	proto.RegisterType((*ActivateSceneRequest)(nil), "apartment.ActivateSceneRequest")
+	proto.RegisterType((*ActivateSceneResponse)(nil), "apartment.ActivateSceneResponse")
+	proto.RegisterType((*Action)(nil), "apartment.Action")
+	proto.RegisterType((*Schedule)(nil), "apartment.Schedule")
+	proto.RegisterType((*CreateScheduleRequest)(nil), "apartment.CreateScheduleRequest")
+	proto.RegisterType((*ListSchedulesRequest)(nil), "apartment.ListSchedulesRequest")
+	proto.RegisterType((*ListSchedulesResponse)(nil), "apartment.ListSchedulesResponse")
+	proto.RegisterType((*DeleteScheduleRequest)(nil), "apartment.DeleteScheduleRequest")
+	proto.RegisterType((*DeleteScheduleResponse)(nil), "apartment.DeleteScheduleResponse")
+	proto.RegisterType((*GetSolarTimesRequest)(nil), "apartment.GetSolarTimesRequest")
+	proto.RegisterType((*SolarTimes)(nil), "apartment.SolarTimes")
+	proto.RegisterType((*Rule)(nil), "apartment.Rule")
+	proto.RegisterType((*Trigger)(nil), "apartment.Trigger")
+	proto.RegisterType((*Condition)(nil), "apartment.Condition")
+	proto.RegisterType((*CreateRuleRequest)(nil), "apartment.CreateRuleRequest")
+	proto.RegisterType((*GetRuleRequest)(nil), "apartment.GetRuleRequest")
+	proto.RegisterType((*ListRulesRequest)(nil), "apartment.ListRulesRequest")
+	proto.RegisterType((*ListRulesResponse)(nil), "apartment.ListRulesResponse")
+	proto.RegisterType((*UpdateRuleRequest)(nil), "apartment.UpdateRuleRequest")
+	proto.RegisterType((*DeleteRuleRequest)(nil), "apartment.DeleteRuleRequest")
+	proto.RegisterType((*DeleteRuleResponse)(nil), "apartment.DeleteRuleResponse")
+	proto.RegisterType((*ListScriptsRequest)(nil), "apartment.ListScriptsRequest")
+	proto.RegisterType((*ListScriptsResponse)(nil), "apartment.ListScriptsResponse")
+	proto.RegisterType((*Script)(nil), "apartment.Script")
+	proto.RegisterType((*SetDeviceStateForRequest)(nil), "apartment.SetDeviceStateForRequest")
+	proto.RegisterType((*Timer)(nil), "apartment.Timer")
+	proto.RegisterType((*ListTimersRequest)(nil), "apartment.ListTimersRequest")
+	proto.RegisterType((*ListTimersResponse)(nil), "apartment.ListTimersResponse")
+	proto.RegisterType((*CancelTimerRequest)(nil), "apartment.CancelTimerRequest")
+	proto.RegisterType((*CancelTimerResponse)(nil), "apartment.CancelTimerResponse")
+	proto.RegisterType((*HistoryRecord)(nil), "apartment.HistoryRecord")
+	proto.RegisterType((*QueryHistoryRequest)(nil), "apartment.QueryHistoryRequest")
+	proto.RegisterType((*QueryHistoryResponse)(nil), "apartment.QueryHistoryResponse")
+	proto.RegisterType((*AddDeviceRequest)(nil), "apartment.AddDeviceRequest")
+	proto.RegisterType((*AddDeviceResponse)(nil), "apartment.AddDeviceResponse")
+	proto.RegisterType((*ForgetDeviceRequest)(nil), "apartment.ForgetDeviceRequest")
+	proto.RegisterType((*ForgetDeviceResponse)(nil), "apartment.ForgetDeviceResponse")
+	proto.RegisterEnum("apartment.PowerState", PowerState_name, PowerState_value)
+	proto.RegisterEnum("apartment.Device.Type", Device_Type_name, Device_Type_value)
+	proto.RegisterEnum("apartment.DeviceEvent.Type", DeviceEvent_Type_name, DeviceEvent_Type_value)
+	proto.RegisterEnum("apartment.Schedule.CatchUp", Schedule_CatchUp_name, Schedule_CatchUp_value)
+	proto.RegisterEnum("apartment.Trigger.Type", Trigger_Type_name, Trigger_Type_value)
+	proto.RegisterEnum("apartment.HistoryRecord.Source", HistoryRecord_Source_name, HistoryRecord_Source_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Apartment service
+
+type ApartmentClient interface {
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	WatchDevices(ctx context.Context, in *WatchDevicesRequest, opts ...grpc.CallOption) (Apartment_WatchDevicesClient, error)
+	RenameDevice(ctx context.Context, in *RenameDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	SetDeviceNames(ctx context.Context, in *SetDeviceNamesRequest, opts ...grpc.CallOption) (*Device, error)
+	AddDevice(ctx context.Context, in *AddDeviceRequest, opts ...grpc.CallOption) (*AddDeviceResponse, error)
+	ForgetDevice(ctx context.Context, in *ForgetDeviceRequest, opts ...grpc.CallOption) (*ForgetDeviceResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*Group, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*Group, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	SetGroupState(ctx context.Context, in *SetGroupStateRequest, opts ...grpc.CallOption) (*SetGroupStateResponse, error)
+	CreateScene(ctx context.Context, in *CreateSceneRequest, opts ...grpc.CallOption) (*Scene, error)
+	ListScenes(ctx context.Context, in *ListScenesRequest, opts ...grpc.CallOption) (*ListScenesResponse, error)
+	DeleteScene(ctx context.Context, in *DeleteSceneRequest, opts ...grpc.CallOption) (*DeleteSceneResponse, error)
+	ActivateScene(ctx context.Context, in *ActivateSceneRequest, opts ...grpc.CallOption) (*ActivateSceneResponse, error)
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error)
+	GetSolarTimes(ctx context.Context, in *GetSolarTimesRequest, opts ...grpc.CallOption) (*SolarTimes, error)
+	CreateRule(ctx context.Context, in *CreateRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	GetRule(ctx context.Context, in *GetRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error)
+	UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*DeleteRuleResponse, error)
+	ListScripts(ctx context.Context, in *ListScriptsRequest, opts ...grpc.CallOption) (*ListScriptsResponse, error)
+	SetDeviceStateFor(ctx context.Context, in *SetDeviceStateForRequest, opts ...grpc.CallOption) (*Device, error)
+	ListTimers(ctx context.Context, in *ListTimersRequest, opts ...grpc.CallOption) (*ListTimersResponse, error)
+	CancelTimer(ctx context.Context, in *CancelTimerRequest, opts ...grpc.CallOption) (*CancelTimerResponse, error)
+	QueryHistory(ctx context.Context, in *QueryHistoryRequest, opts ...grpc.CallOption) (*QueryHistoryResponse, error)
+}
+
+type apartmentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApartmentClient(cc *grpc.ClientConn) ApartmentClient {
+	return &apartmentClient{cc}
+}
+
+func (c *apartmentClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListDevices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/GetDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/UpdateDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) WatchDevices(ctx context.Context, in *WatchDevicesRequest, opts ...grpc.CallOption) (Apartment_WatchDevicesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Apartment_serviceDesc.Streams[0], c.cc, "/apartment.Apartment/WatchDevices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apartmentWatchDevicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Apartment_WatchDevicesClient interface {
+	Recv() (*DeviceEvent, error)
+	grpc.ClientStream
+}
+
+type apartmentWatchDevicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *apartmentWatchDevicesClient) Recv() (*DeviceEvent, error) {
+	m := new(DeviceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *apartmentClient) RenameDevice(ctx context.Context, in *RenameDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/RenameDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) SetDeviceNames(ctx context.Context, in *SetDeviceNamesRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/SetDeviceNames", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) AddDevice(ctx context.Context, in *AddDeviceRequest, opts ...grpc.CallOption) (*AddDeviceResponse, error) {
+	out := new(AddDeviceResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/AddDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ForgetDevice(ctx context.Context, in *ForgetDeviceRequest, opts ...grpc.CallOption) (*ForgetDeviceResponse, error) {
+	out := new(ForgetDeviceResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ForgetDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*Group, error) {
+	out := new(Group)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/CreateGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListGroups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*Group, error) {
+	out := new(Group)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/UpdateGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/DeleteGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) SetGroupState(ctx context.Context, in *SetGroupStateRequest, opts ...grpc.CallOption) (*SetGroupStateResponse, error) {
+	out := new(SetGroupStateResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/SetGroupState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) CreateScene(ctx context.Context, in *CreateSceneRequest, opts ...grpc.CallOption) (*Scene, error) {
+	out := new(Scene)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/CreateScene", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ListScenes(ctx context.Context, in *ListScenesRequest, opts ...grpc.CallOption) (*ListScenesResponse, error) {
+	out := new(ListScenesResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListScenes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) DeleteScene(ctx context.Context, in *DeleteSceneRequest, opts ...grpc.CallOption) (*DeleteSceneResponse, error) {
+	out := new(DeleteSceneResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/DeleteScene", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ActivateScene(ctx context.Context, in *ActivateSceneRequest, opts ...grpc.CallOption) (*ActivateSceneResponse, error) {
+	out := new(ActivateSceneResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ActivateScene", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/CreateSchedule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListSchedules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error) {
+	out := new(DeleteScheduleResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/DeleteSchedule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) GetSolarTimes(ctx context.Context, in *GetSolarTimesRequest, opts ...grpc.CallOption) (*SolarTimes, error) {
+	out := new(SolarTimes)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/GetSolarTimes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) CreateRule(ctx context.Context, in *CreateRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	out := new(Rule)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/CreateRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) GetRule(ctx context.Context, in *GetRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	out := new(Rule)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/GetRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ListRules(ctx context.Context, in *ListRulesRequest, opts ...grpc.CallOption) (*ListRulesResponse, error) {
+	out := new(ListRulesResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListRules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	out := new(Rule)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/UpdateRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*DeleteRuleResponse, error) {
+	out := new(DeleteRuleResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/DeleteRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ListScripts(ctx context.Context, in *ListScriptsRequest, opts ...grpc.CallOption) (*ListScriptsResponse, error) {
+	out := new(ListScriptsResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListScripts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) SetDeviceStateFor(ctx context.Context, in *SetDeviceStateForRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/SetDeviceStateFor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) ListTimers(ctx context.Context, in *ListTimersRequest, opts ...grpc.CallOption) (*ListTimersResponse, error) {
+	out := new(ListTimersResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/ListTimers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) CancelTimer(ctx context.Context, in *CancelTimerRequest, opts ...grpc.CallOption) (*CancelTimerResponse, error) {
+	out := new(CancelTimerResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/CancelTimer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apartmentClient) QueryHistory(ctx context.Context, in *QueryHistoryRequest, opts ...grpc.CallOption) (*QueryHistoryResponse, error) {
+	out := new(QueryHistoryResponse)
+	err := grpc.Invoke(ctx, "/apartment.Apartment/QueryHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Apartment service
+
+type ApartmentServer interface {
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	GetDevice(context.Context, *GetDeviceRequest) (*Device, error)
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*Device, error)
+	WatchDevices(*WatchDevicesRequest, Apartment_WatchDevicesServer) error
+	RenameDevice(context.Context, *RenameDeviceRequest) (*Device, error)
+	SetDeviceNames(context.Context, *SetDeviceNamesRequest) (*Device, error)
+	AddDevice(context.Context, *AddDeviceRequest) (*AddDeviceResponse, error)
+	ForgetDevice(context.Context, *ForgetDeviceRequest) (*ForgetDeviceResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*Group, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*Group, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	SetGroupState(context.Context, *SetGroupStateRequest) (*SetGroupStateResponse, error)
+	CreateScene(context.Context, *CreateSceneRequest) (*Scene, error)
+	ListScenes(context.Context, *ListScenesRequest) (*ListScenesResponse, error)
+	DeleteScene(context.Context, *DeleteSceneRequest) (*DeleteSceneResponse, error)
+	ActivateScene(context.Context, *ActivateSceneRequest) (*ActivateSceneResponse, error)
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*Schedule, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error)
+	GetSolarTimes(context.Context, *GetSolarTimesRequest) (*SolarTimes, error)
+	CreateRule(context.Context, *CreateRuleRequest) (*Rule, error)
+	GetRule(context.Context, *GetRuleRequest) (*Rule, error)
+	ListRules(context.Context, *ListRulesRequest) (*ListRulesResponse, error)
+	UpdateRule(context.Context, *UpdateRuleRequest) (*Rule, error)
+	DeleteRule(context.Context, *DeleteRuleRequest) (*DeleteRuleResponse, error)
+	ListScripts(context.Context, *ListScriptsRequest) (*ListScriptsResponse, error)
+	SetDeviceStateFor(context.Context, *SetDeviceStateForRequest) (*Device, error)
+	ListTimers(context.Context, *ListTimersRequest) (*ListTimersResponse, error)
+	CancelTimer(context.Context, *CancelTimerRequest) (*CancelTimerResponse, error)
+	QueryHistory(context.Context, *QueryHistoryRequest) (*QueryHistoryResponse, error)
+}
+
+func RegisterApartmentServer(s *grpc.Server, srv ApartmentServer) {
+	s.RegisterService(&_Apartment_serviceDesc, srv)
+}
+
+func _Apartment_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
 		FullMethod: "/apartment.Apartment/GetDevice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApartmentServer).GetDevice(ctx, req.(*GetDeviceRequest))
+		return srv.(ApartmentServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/UpdateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_WatchDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDevicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApartmentServer).WatchDevices(m, &apartmentWatchDevicesServer{stream})
+}
+
+type Apartment_WatchDevicesServer interface {
+	Send(*DeviceEvent) error
+	grpc.ServerStream
+}
+
+type apartmentWatchDevicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *apartmentWatchDevicesServer) Send(m *DeviceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Apartment_RenameDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).RenameDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/RenameDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).RenameDevice(ctx, req.(*RenameDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_SetDeviceNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).SetDeviceNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/SetDeviceNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).SetDeviceNames(ctx, req.(*SetDeviceNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_AddDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).AddDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/AddDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).AddDevice(ctx, req.(*AddDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ForgetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ForgetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ForgetDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ForgetDevice(ctx, req.(*ForgetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/CreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_SetGroupState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGroupStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).SetGroupState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/SetGroupState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).SetGroupState(ctx, req.(*SetGroupStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_CreateScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).CreateScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/CreateScene",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).CreateScene(ctx, req.(*CreateSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ListScenes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScenesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListScenes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListScenes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListScenes(ctx, req.(*ListScenesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_DeleteScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).DeleteScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/DeleteScene",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).DeleteScene(ctx, req.(*DeleteSceneRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Apartment_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDeviceRequest)
+func _Apartment_ActivateScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateSceneRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ApartmentServer).UpdateDevice(ctx, in)
+		return srv.(ApartmentServer).ActivateScene(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/apartment.Apartment/UpdateDevice",
+		FullMethod: "/apartment.Apartment/ActivateScene",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ApartmentServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+		return srv.(ApartmentServer).ActivateScene(ctx, req.(*ActivateSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/CreateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/DeleteSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).DeleteSchedule(ctx, req.(*DeleteScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_GetSolarTimes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSolarTimesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).GetSolarTimes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/GetSolarTimes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).GetSolarTimes(ctx, req.(*GetSolarTimesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_CreateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).CreateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/CreateRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).CreateRule(ctx, req.(*CreateRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_GetRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).GetRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/GetRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).GetRule(ctx, req.(*GetRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ListRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListRules(ctx, req.(*ListRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).UpdateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/UpdateRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).UpdateRule(ctx, req.(*UpdateRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/DeleteRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).DeleteRule(ctx, req.(*DeleteRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ListScripts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScriptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListScripts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListScripts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListScripts(ctx, req.(*ListScriptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_SetDeviceStateFor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceStateForRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).SetDeviceStateFor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/SetDeviceStateFor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).SetDeviceStateFor(ctx, req.(*SetDeviceStateForRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_ListTimers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTimersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).ListTimers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/ListTimers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).ListTimers(ctx, req.(*ListTimersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_CancelTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTimerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).CancelTimer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/CancelTimer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).CancelTimer(ctx, req.(*CancelTimerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Apartment_QueryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApartmentServer).QueryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apartment.Apartment/QueryHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApartmentServer).QueryHistory(ctx, req.(*QueryHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -266,30 +3039,302 @@ var _Apartment_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDevice",
 			Handler:    _Apartment_UpdateDevice_Handler,
 		},
+		{
+			MethodName: "RenameDevice",
+			Handler:    _Apartment_RenameDevice_Handler,
+		},
+		{
+			MethodName: "SetDeviceNames",
+			Handler:    _Apartment_SetDeviceNames_Handler,
+		},
+		{
+			MethodName: "AddDevice",
+			Handler:    _Apartment_AddDevice_Handler,
+		},
+		{
+			MethodName: "ForgetDevice",
+			Handler:    _Apartment_ForgetDevice_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _Apartment_CreateGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _Apartment_ListGroups_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _Apartment_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Apartment_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "SetGroupState",
+			Handler:    _Apartment_SetGroupState_Handler,
+		},
+		{
+			MethodName: "CreateScene",
+			Handler:    _Apartment_CreateScene_Handler,
+		},
+		{
+			MethodName: "ListScenes",
+			Handler:    _Apartment_ListScenes_Handler,
+		},
+		{
+			MethodName: "DeleteScene",
+			Handler:    _Apartment_DeleteScene_Handler,
+		},
+		{
+			MethodName: "ActivateScene",
+			Handler:    _Apartment_ActivateScene_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _Apartment_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Apartment_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _Apartment_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "GetSolarTimes",
+			Handler:    _Apartment_GetSolarTimes_Handler,
+		},
+		{
+			MethodName: "CreateRule",
+			Handler:    _Apartment_CreateRule_Handler,
+		},
+		{
+			MethodName: "GetRule",
+			Handler:    _Apartment_GetRule_Handler,
+		},
+		{
+			MethodName: "ListRules",
+			Handler:    _Apartment_ListRules_Handler,
+		},
+		{
+			MethodName: "UpdateRule",
+			Handler:    _Apartment_UpdateRule_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _Apartment_DeleteRule_Handler,
+		},
+		{
+			MethodName: "ListScripts",
+			Handler:    _Apartment_ListScripts_Handler,
+		},
+		{
+			MethodName: "SetDeviceStateFor",
+			Handler:    _Apartment_SetDeviceStateFor_Handler,
+		},
+		{
+			MethodName: "ListTimers",
+			Handler:    _Apartment_ListTimers_Handler,
+		},
+		{
+			MethodName: "CancelTimer",
+			Handler:    _Apartment_CancelTimer_Handler,
+		},
+		{
+			MethodName: "QueryHistory",
+			Handler:    _Apartment_QueryHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDevices",
+			Handler:       _Apartment_WatchDevices_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "apartment.proto",
 }
 
 func init() { proto.RegisterFile("apartment.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xe2, 0xe2, 0x4f, 0x2c, 0x48, 0x2c,
-	0x2a, 0xc9, 0x4d, 0xcd, 0x2b, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x0b, 0x28,
-	0x85, 0x73, 0xb1, 0xb9, 0xa4, 0x96, 0x65, 0x26, 0xa7, 0x0a, 0x09, 0x71, 0xb1, 0xe4, 0x25, 0xe6,
-	0xa6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x81, 0xd9, 0x42, 0xca, 0x5c, 0xbc, 0x69, 0x45,
-	0x99, 0xa9, 0x79, 0x29, 0x39, 0x95, 0xf1, 0x60, 0x49, 0x26, 0xb0, 0x24, 0x0f, 0x4c, 0xd0, 0x0f,
-	0xa4, 0x48, 0x84, 0x8b, 0xb5, 0xb8, 0x24, 0xb1, 0x24, 0x55, 0x82, 0x59, 0x81, 0x51, 0x83, 0x23,
-	0x08, 0xc2, 0x51, 0x12, 0xe1, 0x12, 0xf2, 0xc9, 0x2c, 0x2e, 0x81, 0x18, 0x5e, 0x1c, 0x94, 0x5a,
-	0x58, 0x9a, 0x5a, 0x5c, 0xa2, 0xe4, 0xc0, 0x25, 0x8c, 0x22, 0x5a, 0x5c, 0x90, 0x9f, 0x57, 0x9c,
-	0x2a, 0xa4, 0xc9, 0xc5, 0x96, 0x02, 0x16, 0x92, 0x60, 0x54, 0x60, 0xd6, 0xe0, 0x36, 0x12, 0xd4,
-	0x43, 0x38, 0x19, 0xa2, 0x36, 0x08, 0xaa, 0x40, 0x49, 0x8d, 0x4b, 0xc0, 0x3d, 0x15, 0x6a, 0x00,
-	0xd4, 0x54, 0x6c, 0x4e, 0x07, 0xd9, 0x14, 0x5a, 0x90, 0x92, 0x58, 0x92, 0x8a, 0xaa, 0x14, 0xd9,
-	0x26, 0x46, 0xbc, 0x36, 0x19, 0xdd, 0x67, 0xe4, 0xe2, 0x74, 0x84, 0x49, 0x0a, 0xf9, 0x71, 0x71,
-	0x23, 0xb9, 0x5c, 0x48, 0x16, 0x49, 0x1f, 0xa6, 0x3f, 0xa5, 0xe4, 0x70, 0x49, 0x43, 0x3c, 0xac,
-	0xc4, 0x20, 0x64, 0xcb, 0xc5, 0x09, 0xf7, 0x87, 0x90, 0x34, 0x92, 0x72, 0x74, 0xdf, 0x49, 0x61,
-	0x3a, 0x51, 0x89, 0x41, 0xc8, 0x99, 0x8b, 0x07, 0xd9, 0x7b, 0x42, 0xc8, 0x16, 0x62, 0xf1, 0x37,
-	0x56, 0x43, 0x92, 0xd8, 0xc0, 0xc9, 0xc1, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x1a, 0x36, 0x21,
-	0x5d, 0x21, 0x02, 0x00, 0x00,
+	// 2800 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x5a, 0xdd, 0x72, 0xdb, 0xc6,
+	0xf5, 0x17, 0x08, 0x7e, 0x1e, 0x52, 0x12, 0xb4, 0xa2, 0x64, 0x04, 0xb1, 0x6c, 0x19, 0xfe, 0x8f,
+	0xff, 0x4a, 0xdc, 0x3a, 0x1e, 0xb7, 0x4d, 0xd2, 0x99, 0xb4, 0x63, 0x59, 0xa2, 0x2d, 0xd5, 0x16,
+	0xa9, 0x2c, 0x29, 0x79, 0x72, 0xc5, 0x81, 0x89, 0xb5, 0x84, 0x09, 0x08, 0xb0, 0x0b, 0xd0, 0x8a,
+	0xf2, 0x12, 0x7d, 0x89, 0x4c, 0x5f, 0xa2, 0x17, 0xbd, 0xe8, 0x7b, 0xf4, 0xb2, 0x37, 0x9d, 0xf6,
+	0x19, 0x3a, 0xfb, 0x01, 0x60, 0x41, 0x80, 0x92, 0x93, 0x3b, 0xee, 0x39, 0x07, 0xbb, 0x67, 0x77,
+	0x7f, 0xe7, 0xec, 0xef, 0x1c, 0x09, 0xd6, 0x9d, 0x99, 0x43, 0xe3, 0x29, 0x09, 0xe2, 0x27, 0x33,
+	0x1a, 0xc6, 0x21, 0x6a, 0xa5, 0x02, 0xfb, 0xa7, 0x06, 0xd4, 0x0f, 0xc9, 0x07, 0x6f, 0x42, 0x10,
+	0x82, 0x6a, 0xe0, 0x4c, 0x89, 0xa9, 0xed, 0x6a, 0x7b, 0x2d, 0xcc, 0x7f, 0xa3, 0x87, 0xb0, 0xfa,
+	0x9e, 0x7a, 0x24, 0x70, 0xfd, 0xeb, 0x31, 0x57, 0x56, 0xb8, 0xb2, 0x93, 0x08, 0xfb, 0xcc, 0xa8,
+	0x0b, 0xb5, 0x28, 0x76, 0x62, 0x62, 0xea, 0xbb, 0xda, 0x5e, 0x13, 0x8b, 0x01, 0xfa, 0x35, 0xd4,
+	0x66, 0xe1, 0x15, 0xa1, 0x66, 0x75, 0x57, 0xdb, 0x6b, 0x3f, 0xbb, 0xf3, 0x24, 0xf3, 0xe2, 0x94,
+	0xc9, 0x31, 0x71, 0x5c, 0x2f, 0xb8, 0xc0, 0xc2, 0x0a, 0x7d, 0x09, 0x6d, 0xfe, 0x63, 0x2c, 0xa6,
+	0xaa, 0xed, 0x6a, 0x7b, 0x6b, 0xcf, 0xb6, 0x16, 0x3f, 0x1a, 0x32, 0x25, 0x86, 0x59, 0xfa, 0x1b,
+	0xdd, 0x03, 0x78, 0x47, 0xbd, 0x8b, 0xcb, 0x38, 0x20, 0x51, 0x64, 0xd6, 0x77, 0xb5, 0xbd, 0x1a,
+	0x56, 0x24, 0xc8, 0x82, 0xa6, 0xeb, 0x4d, 0xa7, 0xce, 0x3b, 0x9f, 0x98, 0x0d, 0xee, 0x5f, 0x3a,
+	0x46, 0x9f, 0x43, 0x35, 0xbe, 0x9e, 0x11, 0xb3, 0xc9, 0x17, 0xdb, 0x56, 0x16, 0x13, 0x47, 0xf2,
+	0x64, 0x74, 0x3d, 0x23, 0x98, 0xdb, 0xa0, 0xcf, 0xa0, 0x1e, 0x91, 0x20, 0x0a, 0xa9, 0xd9, 0xe2,
+	0xfb, 0xd9, 0x50, 0xac, 0x87, 0x5c, 0x81, 0xa5, 0x01, 0xba, 0x0b, 0xad, 0x69, 0xc8, 0x14, 0x0e,
+	0xbd, 0x36, 0x81, 0xaf, 0x99, 0x09, 0xd0, 0xa7, 0xd0, 0xa2, 0xc4, 0x71, 0xc7, 0x61, 0xe0, 0x5f,
+	0x9b, 0x6d, 0xe1, 0x11, 0x13, 0x0c, 0x02, 0xff, 0x1a, 0x3d, 0x86, 0x8d, 0x49, 0xe8, 0x87, 0x74,
+	0x1c, 0x93, 0xe9, 0x8c, 0x50, 0x27, 0x9e, 0x53, 0x62, 0x76, 0xf8, 0xa6, 0x0c, 0xae, 0x18, 0x65,
+	0x72, 0x64, 0x42, 0x23, 0x9e, 0x07, 0x7c, 0x67, 0xab, 0x7c, 0x9e, 0x64, 0x88, 0x0c, 0xd0, 0xe7,
+	0x6e, 0x60, 0xae, 0xf1, 0xcb, 0x62, 0x3f, 0xd9, 0x45, 0x46, 0x84, 0x7a, 0x8e, 0x3f, 0x0e, 0xe6,
+	0xd3, 0x77, 0x84, 0x9a, 0xeb, 0xe2, 0x22, 0x85, 0xb0, 0xcf, 0x65, 0xe8, 0x3e, 0xb4, 0xa7, 0xce,
+	0x64, 0xec, 0xb8, 0x2e, 0x65, 0x87, 0x69, 0x70, 0x13, 0x98, 0x3a, 0x93, 0x7d, 0x21, 0x41, 0x3b,
+	0x00, 0xd3, 0xd0, 0x25, 0xbe, 0xc0, 0xc2, 0x06, 0xd7, 0xb7, 0xb8, 0x84, 0x03, 0xe1, 0x01, 0x74,
+	0xa4, 0x5a, 0xac, 0x81, 0xb8, 0x41, 0x5b, 0x18, 0x88, 0x25, 0x3e, 0x03, 0xe3, 0xbd, 0x47, 0xa7,
+	0x57, 0x0e, 0x25, 0xe3, 0x0f, 0x84, 0x46, 0x5e, 0x18, 0x98, 0x9b, 0xdc, 0x6c, 0x3d, 0x91, 0x9f,
+	0x0b, 0x31, 0xf3, 0xc6, 0xe5, 0xd7, 0x30, 0xe6, 0x97, 0xd4, 0x15, 0xde, 0x08, 0x11, 0xbb, 0x18,
+	0xf4, 0x09, 0x34, 0xbd, 0x49, 0x18, 0x8c, 0xe7, 0xd4, 0x37, 0xb7, 0xb8, 0xb6, 0xc1, 0xc6, 0x67,
+	0xd4, 0x67, 0x9e, 0xb8, 0x5e, 0x34, 0xf3, 0x1d, 0x09, 0xdb, 0x6d, 0xe1, 0x89, 0x94, 0x71, 0x67,
+	0x4d, 0x68, 0x38, 0xbe, 0xe7, 0x44, 0x24, 0x32, 0xef, 0xec, 0xea, 0xec, 0x63, 0x39, 0x44, 0x8f,
+	0xa0, 0x16, 0x7b, 0x53, 0x42, 0x4d, 0x93, 0xdf, 0xb4, 0xa1, 0xdc, 0xf4, 0x88, 0xc9, 0xb1, 0x50,
+	0xdb, 0x1e, 0x54, 0xb9, 0x1f, 0x2d, 0xa8, 0x0d, 0x46, 0x47, 0x3d, 0x6c, 0xac, 0x20, 0x80, 0xfa,
+	0xf0, 0xed, 0xf1, 0xe8, 0xe0, 0xc8, 0xd0, 0x50, 0x1b, 0x1a, 0xc7, 0xfd, 0xe1, 0xf1, 0xab, 0xa3,
+	0x91, 0x51, 0x61, 0x8a, 0xc3, 0xe3, 0x93, 0x93, 0x1e, 0x36, 0x74, 0x64, 0x40, 0xe7, 0x0d, 0x13,
+	0x8f, 0xa5, 0x69, 0x95, 0xcd, 0x70, 0xb2, 0xff, 0xba, 0x87, 0x8d, 0x1a, 0x33, 0x3c, 0x19, 0x8c,
+	0x8e, 0x07, 0x7d, 0xa3, 0x8e, 0x9a, 0x50, 0x7d, 0x71, 0xf6, 0xe6, 0x85, 0xd1, 0xb0, 0x9f, 0x43,
+	0x5d, 0x80, 0x8c, 0xb9, 0x3d, 0xa3, 0x24, 0x22, 0x41, 0xcc, 0x03, 0xb5, 0x89, 0x93, 0x21, 0x83,
+	0x5d, 0x4c, 0xbd, 0x8b, 0x0b, 0x42, 0x89, 0xcb, 0xe3, 0xb4, 0x89, 0x33, 0x81, 0xfd, 0x0f, 0x0d,
+	0x3a, 0x6a, 0xdc, 0xb1, 0xbb, 0x9c, 0xcc, 0x29, 0x25, 0x41, 0x3c, 0x9e, 0x5e, 0xf1, 0xb9, 0x74,
+	0xdc, 0x92, 0x92, 0x93, 0x2b, 0x06, 0xd3, 0x38, 0x74, 0x9d, 0xeb, 0xf1, 0xf7, 0x57, 0x97, 0x7c,
+	0x36, 0x0d, 0x37, 0xb9, 0xe0, 0xf5, 0xd5, 0xa5, 0x50, 0xc6, 0x8e, 0xcf, 0x95, 0x7a, 0xa2, 0x8c,
+	0x1d, 0x9f, 0x29, 0xf7, 0xc0, 0x08, 0x83, 0xb1, 0xf8, 0x38, 0x22, 0x93, 0x30, 0x70, 0x23, 0x9e,
+	0x03, 0x74, 0xbc, 0x16, 0x06, 0x23, 0x26, 0x1e, 0x0a, 0x29, 0x7a, 0x0a, 0xdd, 0x28, 0x76, 0x02,
+	0xf7, 0xdd, 0xf5, 0x38, 0xbe, 0xa4, 0x24, 0xba, 0x0c, 0x7d, 0x97, 0x39, 0x53, 0xe3, 0xd6, 0x48,
+	0xea, 0x46, 0x89, 0xea, 0xe4, 0xca, 0xee, 0x02, 0x7a, 0xe3, 0x45, 0xb1, 0x08, 0xcf, 0x08, 0x93,
+	0x3f, 0xcf, 0x49, 0x14, 0xdb, 0xcf, 0x61, 0x33, 0x27, 0x8d, 0x66, 0x61, 0x10, 0xf1, 0x90, 0x15,
+	0x68, 0x31, 0xb5, 0x5d, 0x7d, 0x21, 0x64, 0x85, 0x2d, 0x96, 0x06, 0xf6, 0x23, 0x30, 0x5e, 0x11,
+	0x39, 0x81, 0x9c, 0xb5, 0x2c, 0x1f, 0xda, 0xdf, 0xc3, 0xe6, 0xd9, 0xcc, 0x75, 0x62, 0x92, 0x37,
+	0x55, 0x57, 0xd2, 0x6e, 0x5c, 0x89, 0x05, 0xc0, 0x9c, 0xcf, 0x30, 0xce, 0x72, 0x84, 0xb8, 0xac,
+	0x75, 0x21, 0x3f, 0x49, 0xc4, 0xf6, 0x16, 0x6c, 0xbe, 0x75, 0xe2, 0xc9, 0xe5, 0xc2, 0x6e, 0xff,
+	0xa6, 0x41, 0x5b, 0x88, 0x7a, 0x1f, 0xd8, 0xbd, 0x7f, 0x21, 0xb3, 0x98, 0xc6, 0xb3, 0xd8, 0xa7,
+	0x85, 0xa5, 0xb9, 0xd5, 0x42, 0x2a, 0x93, 0xde, 0x56, 0x6e, 0xf1, 0xd6, 0x3e, 0x95, 0x10, 0x6f,
+	0x43, 0xe3, 0xac, 0xff, 0xba, 0x3f, 0x78, 0xdb, 0x37, 0x56, 0xd0, 0x1a, 0xc0, 0xe1, 0xf1, 0xf0,
+	0x60, 0x70, 0xde, 0xc3, 0xbd, 0x43, 0x01, 0x74, 0xdc, 0x3b, 0x19, 0x9c, 0xf7, 0x0e, 0x8d, 0x0a,
+	0xda, 0x80, 0xd5, 0xe1, 0x68, 0x7f, 0xd4, 0x1b, 0x1f, 0x1c, 0xed, 0xf7, 0x5f, 0xf5, 0x0e, 0x0d,
+	0x5d, 0xe8, 0xfb, 0xfb, 0x27, 0xbd, 0x43, 0xa3, 0x6a, 0xf7, 0x61, 0x13, 0x13, 0x76, 0x96, 0xb7,
+	0x1e, 0xf6, 0x47, 0x3d, 0x3e, 0xf6, 0x25, 0x6c, 0x0d, 0x93, 0x9b, 0x63, 0x82, 0xe8, 0xa6, 0x19,
+	0x17, 0xd3, 0x42, 0xe5, 0xc6, 0xb4, 0xa0, 0xe7, 0xd2, 0x82, 0xfd, 0x3b, 0xa8, 0xbd, 0xa2, 0xe1,
+	0x7c, 0x56, 0x3a, 0xb3, 0x09, 0x0d, 0x71, 0x64, 0x91, 0x59, 0x11, 0x9f, 0xc9, 0xa1, 0xfd, 0x0d,
+	0xa0, 0x03, 0x4a, 0x9c, 0x98, 0xf0, 0x8f, 0x13, 0xef, 0x1e, 0x41, 0xed, 0x82, 0x8d, 0x4d, 0xad,
+	0x90, 0x63, 0x84, 0x9d, 0x50, 0xdb, 0x9b, 0xb0, 0xc1, 0xa0, 0xcd, 0x65, 0x29, 0x02, 0xbe, 0x01,
+	0xa4, 0x0a, 0x25, 0xdc, 0x95, 0x29, 0xf5, 0x9b, 0xa6, 0xfc, 0x06, 0x90, 0xc0, 0xf0, 0x2f, 0x72,
+	0x68, 0x0f, 0xd0, 0x21, 0xf1, 0xc9, 0xc2, 0xd7, 0x65, 0xb1, 0xb2, 0x05, 0x9b, 0x39, 0x4b, 0xe1,
+	0xa6, 0xfd, 0x1c, 0xba, 0x43, 0x22, 0x7c, 0x17, 0xaf, 0xf9, 0x0d, 0xf7, 0x95, 0x32, 0x8b, 0x8a,
+	0xc2, 0x2c, 0xec, 0x23, 0xd8, 0x5a, 0x98, 0x41, 0x9e, 0xc0, 0x17, 0x50, 0xa7, 0x24, 0x9a, 0xfb,
+	0xb1, 0x3c, 0x82, 0x3b, 0x45, 0x60, 0x73, 0x35, 0x96, 0x66, 0xf6, 0x5f, 0x34, 0xe8, 0xa8, 0x8a,
+	0xe4, 0xe1, 0xd4, 0xb2, 0x87, 0x33, 0x71, 0xab, 0x92, 0xbf, 0xec, 0x68, 0x3e, 0x99, 0xb0, 0x37,
+	0x52, 0x50, 0x9e, 0x64, 0xc8, 0x1c, 0x26, 0x94, 0x86, 0x82, 0xf4, 0xb4, 0xb0, 0x18, 0x28, 0x01,
+	0x57, 0xbb, 0x2d, 0xe0, 0x4e, 0xa0, 0x36, 0x9c, 0x90, 0xa0, 0x9c, 0x8d, 0x3d, 0xcd, 0x83, 0xac,
+	0x9d, 0xa3, 0x2c, 0xfc, 0x33, 0x39, 0x5b, 0x0a, 0xbe, 0x33, 0x68, 0x2b, 0xf2, 0x92, 0xed, 0x95,
+	0x9e, 0xf0, 0x02, 0xa9, 0xd2, 0x17, 0x49, 0x95, 0xfd, 0x22, 0xc1, 0x34, 0x9f, 0xfc, 0xa6, 0x1b,
+	0x5c, 0x1e, 0x17, 0x12, 0xd9, 0x7c, 0x86, 0x45, 0x64, 0x27, 0xc2, 0x0c, 0xd9, 0x11, 0x93, 0x94,
+	0x20, 0x5b, 0x38, 0x20, 0xd4, 0x19, 0x36, 0x6f, 0x73, 0x2b, 0xc3, 0xa6, 0xb4, 0x94, 0xd8, 0xfc,
+	0x1c, 0xba, 0xfb, 0x93, 0xd8, 0xfb, 0xf0, 0x11, 0x3b, 0x63, 0x28, 0x5c, 0xb0, 0xfd, 0xa5, 0x28,
+	0xfc, 0xbb, 0x06, 0x75, 0x36, 0x55, 0x18, 0xa0, 0xed, 0xdc, 0x43, 0xd2, 0x4a, 0x5f, 0x8d, 0x6e,
+	0x12, 0x9d, 0x02, 0x86, 0x62, 0xc0, 0x2f, 0x8f, 0x9f, 0x8b, 0x2e, 0xa4, 0x7c, 0x90, 0x5d, 0x69,
+	0x75, 0xf9, 0x95, 0xd6, 0x0a, 0x3c, 0xf9, 0x21, 0xac, 0xba, 0xc4, 0x57, 0x9e, 0xec, 0x3a, 0x7f,
+	0x84, 0x3b, 0x5c, 0x98, 0x3c, 0xd8, 0xdb, 0x50, 0x0f, 0xc2, 0xd8, 0x7b, 0x7f, 0xcd, 0xa9, 0x74,
+	0x0b, 0xcb, 0x91, 0xfd, 0x5f, 0x0d, 0x9a, 0xc3, 0xc9, 0x25, 0x71, 0xe7, 0x7e, 0x39, 0x72, 0x11,
+	0x54, 0xa3, 0x19, 0x99, 0x24, 0x51, 0xc4, 0x7e, 0xb3, 0xa8, 0x70, 0xf8, 0xae, 0x4d, 0xbd, 0x10,
+	0x15, 0xe2, 0x38, 0xb0, 0x34, 0x40, 0x5f, 0x42, 0x73, 0xc2, 0x5e, 0xc2, 0xf1, 0x7c, 0x66, 0x56,
+	0x0b, 0xcf, 0x5c, 0xb2, 0xf2, 0x93, 0x03, 0x66, 0x73, 0x36, 0xc3, 0x8d, 0x89, 0xf8, 0xc1, 0x18,
+	0xa2, 0xef, 0x44, 0xf1, 0x98, 0xce, 0x03, 0x49, 0x2a, 0x1a, 0x6c, 0x8c, 0xe7, 0x01, 0x53, 0x05,
+	0xe4, 0x07, 0xa1, 0x12, 0x5b, 0x6d, 0xb0, 0x31, 0x9e, 0x07, 0xf6, 0x03, 0x68, 0xc8, 0x99, 0x18,
+	0x03, 0x1b, 0xbe, 0x3e, 0x3e, 0x35, 0x56, 0x50, 0x07, 0x9a, 0xf8, 0xac, 0x3f, 0x1e, 0xf4, 0x0f,
+	0x7a, 0x86, 0xc6, 0x2e, 0x3f, 0x09, 0x00, 0xb1, 0x76, 0x82, 0x94, 0x2f, 0xa0, 0x19, 0x49, 0x91,
+	0xcc, 0xa4, 0x9b, 0x25, 0x9e, 0xe2, 0xd4, 0xc8, 0xde, 0x86, 0xae, 0x40, 0xbc, 0x18, 0xa7, 0x91,
+	0x70, 0x04, 0x5b, 0x0b, 0xf2, 0x14, 0x5e, 0xea, 0x0a, 0xfa, 0xed, 0x2b, 0x3c, 0x86, 0xad, 0x04,
+	0xeb, 0x79, 0x5f, 0xcb, 0x50, 0x6d, 0xc2, 0xf6, 0xa2, 0xb1, 0x8c, 0x8d, 0x6d, 0xe8, 0xbe, 0x22,
+	0xf1, 0x30, 0xf4, 0x1d, 0xca, 0x58, 0x70, 0xea, 0xe8, 0x5f, 0x35, 0x80, 0x4c, 0xca, 0x9e, 0x58,
+	0x7e, 0xae, 0xd1, 0x3c, 0xa0, 0x5e, 0x44, 0x24, 0xb1, 0x6c, 0x33, 0xd9, 0x50, 0x88, 0x18, 0xb1,
+	0x4f, 0x4c, 0x22, 0x12, 0x73, 0x4c, 0xe8, 0x18, 0xa4, 0x45, 0x44, 0x62, 0x56, 0xb3, 0xf9, 0x4e,
+	0xec, 0xc5, 0x73, 0x97, 0x24, 0xec, 0x32, 0x19, 0x33, 0x96, 0xeb, 0x87, 0xc1, 0x85, 0x50, 0x56,
+	0xb9, 0x32, 0x13, 0xb0, 0x2f, 0x63, 0x6f, 0x4a, 0x7e, 0x0c, 0x03, 0x91, 0x6b, 0x5b, 0x38, 0x1d,
+	0xdb, 0xff, 0xd2, 0xa0, 0x8a, 0x97, 0x01, 0x94, 0x97, 0x89, 0x11, 0x2b, 0x9e, 0x12, 0xee, 0x9c,
+	0x8e, 0xd1, 0xaf, 0xa0, 0x21, 0x79, 0xb4, 0x44, 0x2a, 0x52, 0x2b, 0x02, 0xa1, 0xc1, 0x89, 0x09,
+	0xfa, 0x2d, 0x00, 0x0b, 0x16, 0x8f, 0x01, 0x97, 0x11, 0x5f, 0x76, 0x43, 0x5d, 0xe5, 0x83, 0x83,
+	0x44, 0x89, 0x15, 0x3b, 0xf4, 0x18, 0x1a, 0x02, 0xeb, 0x2c, 0x36, 0xf5, 0xf2, 0x68, 0x48, 0x2c,
+	0x18, 0x75, 0xe7, 0xb0, 0x7e, 0xef, 0x31, 0xaa, 0x2f, 0xd0, 0xdb, 0x62, 0x92, 0x97, 0x4c, 0x60,
+	0xff, 0x47, 0x83, 0x86, 0x74, 0x0b, 0x3d, 0xce, 0x91, 0xc3, 0x3b, 0x45, 0xc7, 0x55, 0x62, 0xb8,
+	0x9d, 0x23, 0x86, 0xb9, 0xec, 0x53, 0x52, 0xe0, 0x27, 0x31, 0x5d, 0x55, 0x62, 0xfa, 0x01, 0x74,
+	0x4a, 0x98, 0x7c, 0x3b, 0x56, 0x28, 0x7c, 0x42, 0x29, 0x0d, 0xe8, 0x1c, 0xf6, 0xce, 0x8f, 0x0f,
+	0x7a, 0x63, 0xce, 0x17, 0x65, 0xf1, 0xd4, 0xeb, 0x0f, 0x07, 0xd8, 0xd0, 0x58, 0xe0, 0x8d, 0x8e,
+	0x4f, 0x7a, 0x46, 0x05, 0xad, 0x43, 0xfb, 0x74, 0xf0, 0xb6, 0x87, 0xc7, 0xfb, 0x2f, 0x06, 0xe7,
+	0x3d, 0x43, 0xcf, 0x04, 0x2f, 0x7a, 0x6f, 0x06, 0x6f, 0x8d, 0xaa, 0x7d, 0x01, 0xad, 0xf4, 0x50,
+	0x99, 0xaf, 0xce, 0xfb, 0x98, 0x50, 0x79, 0xbb, 0x62, 0xc0, 0x76, 0xf6, 0x8e, 0xbc, 0x0f, 0x69,
+	0xba, 0x33, 0x31, 0x52, 0x76, 0xac, 0x97, 0xef, 0x58, 0xcd, 0xa1, 0xf6, 0xd7, 0xb0, 0x21, 0xa2,
+	0x1e, 0x2b, 0x51, 0xf4, 0x10, 0xaa, 0x34, 0x8b, 0xf6, 0x75, 0xe5, 0x84, 0xb9, 0x15, 0x57, 0xda,
+	0xff, 0x07, 0x6b, 0xaf, 0x48, 0x8c, 0x6f, 0x09, 0x3e, 0x04, 0x06, 0x8b, 0x79, 0xac, 0xe6, 0x81,
+	0xaf, 0x61, 0x43, 0x91, 0xc9, 0x1c, 0x90, 0xad, 0xa9, 0x2f, 0x5f, 0xf3, 0x6b, 0xd8, 0x10, 0x3c,
+	0xef, 0x67, 0x7b, 0xfb, 0xff, 0xb0, 0x21, 0x92, 0xc0, 0x6d, 0x0e, 0x77, 0x01, 0xa9, 0x86, 0x32,
+	0x53, 0x74, 0x93, 0x47, 0x9c, 0x7a, 0xb3, 0x78, 0xb1, 0x48, 0x4b, 0xa5, 0x59, 0x91, 0x16, 0x71,
+	0x51, 0x49, 0x91, 0x26, 0x6c, 0xb1, 0x34, 0xb0, 0x3d, 0xa8, 0x0b, 0x49, 0x69, 0x04, 0x6f, 0x43,
+	0xdd, 0x0f, 0x1d, 0x57, 0xc6, 0xaf, 0x8e, 0xe5, 0x28, 0xa3, 0x64, 0xba, 0x4a, 0xc9, 0x76, 0x00,
+	0xf8, 0x8f, 0x31, 0x4b, 0x0f, 0xb2, 0x3c, 0x6d, 0x71, 0x09, 0x4b, 0x63, 0xf6, 0x0c, 0xcc, 0xb4,
+	0xaa, 0xe0, 0x1c, 0xf3, 0x65, 0x48, 0x7f, 0x59, 0xb1, 0xe7, 0xce, 0xa9, 0xc3, 0x80, 0x99, 0xbe,
+	0xab, 0xc2, 0xbb, 0xf5, 0x44, 0x2e, 0x9f, 0x56, 0xfb, 0x1c, 0x6a, 0x6c, 0x65, 0x5a, 0xc2, 0xd1,
+	0x1e, 0x40, 0x87, 0x92, 0x0f, 0x84, 0xc6, 0x63, 0x95, 0xaa, 0xb5, 0x85, 0x8c, 0x7b, 0xc7, 0x68,
+	0x16, 0xf9, 0x61, 0xe6, 0x51, 0x22, 0xd8, 0x9a, 0x8e, 0x93, 0x61, 0x42, 0xb3, 0xf8, 0xdc, 0x8b,
+	0x34, 0x2b, 0x11, 0x66, 0x34, 0x4b, 0xf4, 0x3d, 0x8a, 0x34, 0x2b, 0xd7, 0xf7, 0xd8, 0x03, 0x74,
+	0xe0, 0x04, 0x13, 0xe2, 0x0b, 0xe9, 0xcd, 0x34, 0x2b, 0x67, 0x29, 0x01, 0xf2, 0xef, 0x0a, 0xac,
+	0x1e, 0x79, 0x51, 0x1c, 0xd2, 0x6b, 0x4c, 0x26, 0x21, 0x75, 0xd9, 0xc7, 0xb1, 0x27, 0x3f, 0xd6,
+	0x31, 0xff, 0x9d, 0x1c, 0x44, 0xa5, 0xc8, 0xc5, 0x75, 0xe5, 0xda, 0x9f, 0x41, 0x8b, 0xe5, 0x9a,
+	0x2c, 0x5a, 0x97, 0x76, 0x0d, 0x9b, 0xa1, 0xef, 0xf2, 0x5f, 0xec, 0x9b, 0x80, 0x5c, 0x7d, 0x4c,
+	0xa7, 0xb1, 0x19, 0x90, 0x2b, 0xf1, 0xcd, 0x57, 0x50, 0x8f, 0xc2, 0x39, 0x9d, 0x10, 0x9e, 0x6f,
+	0xd7, 0x9e, 0xdd, 0x57, 0x3e, 0xc8, 0xed, 0xe5, 0xc9, 0x90, 0x9b, 0x61, 0x69, 0x2e, 0x52, 0x4c,
+	0xec, 0x78, 0x7e, 0xc2, 0x99, 0xc4, 0xc8, 0xbe, 0x80, 0xba, 0xb0, 0xcc, 0x17, 0xd7, 0x0d, 0xd0,
+	0xf7, 0x4f, 0x8f, 0x0d, 0x8d, 0x11, 0x8e, 0xe1, 0xc1, 0x51, 0xef, 0xf0, 0xec, 0x0d, 0xcb, 0x82,
+	0x4d, 0xa8, 0x62, 0xf6, 0x4b, 0xe7, 0x59, 0xf2, 0x00, 0x1f, 0x9f, 0x8e, 0x44, 0xdf, 0x88, 0x65,
+	0x49, 0xd6, 0x37, 0x6a, 0x41, 0xad, 0x77, 0xde, 0xeb, 0x8f, 0x8c, 0x3a, 0x5a, 0x85, 0x56, 0x52,
+	0x9f, 0x7f, 0x67, 0x34, 0xec, 0x7f, 0x6a, 0xb0, 0xf9, 0xed, 0x9c, 0xd0, 0xeb, 0xd4, 0x4d, 0x71,
+	0x61, 0xcb, 0xb8, 0xe6, 0x0e, 0x40, 0x14, 0x3b, 0x34, 0x16, 0xa1, 0x21, 0xe0, 0xda, 0xe2, 0x12,
+	0x76, 0x8b, 0x8c, 0x38, 0x91, 0xc0, 0x15, 0xca, 0x04, 0x6b, 0x81, 0xcb, 0x55, 0xbf, 0x87, 0x86,
+	0xd8, 0xb4, 0x78, 0xf7, 0x3e, 0xe2, 0x90, 0x12, 0x7b, 0xd6, 0x51, 0x9a, 0x39, 0x17, 0x64, 0x1c,
+	0x79, 0x3f, 0x12, 0xc9, 0x4e, 0x9b, 0x4c, 0x30, 0xf4, 0x7e, 0xe4, 0x1e, 0x71, 0x65, 0x1c, 0x7e,
+	0x4f, 0x04, 0x5b, 0x6b, 0x61, 0x6e, 0x3e, 0x62, 0x02, 0x7b, 0x06, 0xdd, 0xfc, 0xfe, 0x24, 0x9e,
+	0x9f, 0x32, 0x22, 0xce, 0x56, 0x93, 0x80, 0x36, 0x97, 0x79, 0x83, 0xa5, 0x1d, 0x7a, 0x04, 0xeb,
+	0x9c, 0x99, 0x28, 0xab, 0x09, 0xf8, 0xad, 0x32, 0xf1, 0x69, 0xba, 0xe2, 0x23, 0x30, 0xf6, 0x5d,
+	0xb7, 0xd0, 0xc1, 0xb8, 0x0c, 0xa3, 0x38, 0xc1, 0x3f, 0xfb, 0x6d, 0xff, 0x11, 0x36, 0x14, 0xbb,
+	0x9f, 0xdf, 0x96, 0xfa, 0x0c, 0x36, 0x5f, 0x86, 0xf4, 0xe2, 0x63, 0x3a, 0x53, 0xdb, 0xd0, 0xcd,
+	0x9b, 0x8a, 0xd5, 0x3e, 0xff, 0x0a, 0x20, 0xc3, 0x73, 0x01, 0x6a, 0x83, 0x97, 0x2f, 0x0d, 0x0d,
+	0xd5, 0xa1, 0x32, 0xe8, 0x1b, 0x15, 0xa6, 0x1d, 0x8e, 0xf6, 0xfb, 0x87, 0x2f, 0xbe, 0x33, 0xf4,
+	0x67, 0x3f, 0x21, 0x68, 0xed, 0x27, 0x8e, 0xa1, 0x3e, 0xb4, 0x95, 0x16, 0x1b, 0xda, 0x51, 0x7c,
+	0x2e, 0x36, 0xe4, 0xac, 0x7b, 0xcb, 0xd4, 0x32, 0x01, 0xac, 0xa0, 0x3f, 0x40, 0x2b, 0x6d, 0xb8,
+	0x21, 0x95, 0xcc, 0x2f, 0xb6, 0xe1, 0xac, 0xe2, 0xf1, 0xd8, 0x2b, 0xe8, 0x00, 0x3a, 0x6a, 0x1f,
+	0x0e, 0xa9, 0x0b, 0x96, 0x34, 0xe8, 0xca, 0x27, 0xf9, 0x13, 0x74, 0xd4, 0xfe, 0x5a, 0x6e, 0x92,
+	0x92, 0xc6, 0x9b, 0xb5, 0x5d, 0xde, 0x5a, 0xb3, 0x57, 0x9e, 0x6a, 0xcc, 0x21, 0xb5, 0xad, 0x95,
+	0x9b, 0xab, 0xa4, 0xdf, 0x55, 0xee, 0xd0, 0x2b, 0x58, 0xcb, 0xf7, 0xb2, 0xd0, 0x6e, 0xee, 0xaf,
+	0x0c, 0x25, 0x6d, 0xae, 0xf2, 0x89, 0x8e, 0xa0, 0x95, 0xe2, 0x2e, 0x77, 0xba, 0x8b, 0xa8, 0xb5,
+	0xee, 0x96, 0x2b, 0xd3, 0x7b, 0xfa, 0x16, 0x3a, 0x2a, 0xac, 0x72, 0xfb, 0x2a, 0x81, 0xa6, 0x75,
+	0x7f, 0xa9, 0x3e, 0x9d, 0xf2, 0x39, 0xb4, 0x95, 0x86, 0x58, 0x0e, 0x4a, 0xc5, 0x46, 0x99, 0x55,
+	0x68, 0x44, 0xd9, 0x2b, 0xe8, 0x35, 0x40, 0xd6, 0xff, 0x42, 0x77, 0x17, 0xc0, 0x96, 0xeb, 0x95,
+	0x59, 0x3b, 0x4b, 0xb4, 0xaa, 0x3b, 0x4a, 0x3b, 0x2c, 0xe7, 0x4e, 0xb1, 0x4d, 0x56, 0xea, 0x4e,
+	0x1f, 0xda, 0x82, 0x05, 0x15, 0x67, 0x28, 0xb6, 0xca, 0xac, 0x7b, 0xcb, 0xd4, 0xa9, 0x47, 0x23,
+	0x58, 0xcd, 0xf5, 0xb7, 0xd0, 0xfd, 0x3c, 0x0a, 0x0a, 0xbd, 0x33, 0x6b, 0x77, 0xb9, 0x41, 0xf1,
+	0xd8, 0x45, 0x7f, 0xa9, 0x78, 0xec, 0x6a, 0xc7, 0xc3, 0x2a, 0xf4, 0x58, 0xb2, 0x63, 0xe7, 0xc3,
+	0xe2, 0xb1, 0xe7, 0x1a, 0x39, 0xd6, 0xce, 0x12, 0x6d, 0xea, 0x4e, 0x7a, 0x68, 0x45, 0x77, 0x8a,
+	0x3d, 0x1c, 0xeb, 0xde, 0x32, 0xb5, 0x7a, 0x68, 0xb9, 0x76, 0x4c, 0xee, 0xd0, 0xca, 0x9a, 0x3a,
+	0xd6, 0xee, 0x72, 0x83, 0x74, 0xd6, 0x63, 0x58, 0xcb, 0xd7, 0xf9, 0xb9, 0x88, 0x2c, 0x6d, 0x01,
+	0x58, 0x65, 0xe5, 0xb8, 0x70, 0x30, 0x57, 0xd0, 0xe7, 0x1c, 0x2c, 0x6b, 0x01, 0x58, 0xbb, 0xcb,
+	0x0d, 0x52, 0x07, 0xdf, 0xc2, 0x5a, 0xbe, 0x5e, 0xcf, 0x39, 0x58, 0x5a, 0xf7, 0x5b, 0x0f, 0x6e,
+	0xb0, 0x50, 0x76, 0xbe, 0x9a, 0x2b, 0xf7, 0x73, 0xee, 0x96, 0x35, 0x02, 0x2c, 0x95, 0x42, 0x65,
+	0x5a, 0x9e, 0xeb, 0x21, 0x2b, 0x9b, 0x72, 0xb8, 0x29, 0x54, 0x53, 0xd6, 0x62, 0x45, 0x62, 0xaf,
+	0xa0, 0xaf, 0xa0, 0x21, 0x6b, 0x27, 0xf4, 0x49, 0xde, 0x87, 0x5b, 0x3e, 0x3c, 0x82, 0x56, 0x5a,
+	0x3a, 0xe5, 0xb2, 0xe0, 0x62, 0x91, 0x65, 0xdd, 0x2d, 0x57, 0x2a, 0xaf, 0x15, 0x64, 0xa5, 0x54,
+	0x6e, 0x07, 0x85, 0x0a, 0xab, 0xcc, 0x91, 0xd7, 0x00, 0x59, 0x99, 0x94, 0xfb, 0xbc, 0x50, 0x66,
+	0x59, 0x3b, 0x4b, 0xb4, 0x6a, 0xe0, 0x28, 0x75, 0x14, 0x2a, 0x06, 0x9a, 0x5a, 0x75, 0x59, 0xf7,
+	0x96, 0xa9, 0xd3, 0xf9, 0x4e, 0x60, 0xa3, 0x50, 0xea, 0xa0, 0x87, 0x65, 0xef, 0xce, 0x42, 0x21,
+	0x54, 0xfe, 0xf4, 0xc8, 0x24, 0x21, 0x4a, 0x8b, 0x42, 0x92, 0xc8, 0x95, 0x21, 0xd6, 0xce, 0x12,
+	0xad, 0xba, 0x57, 0xa5, 0x7e, 0xc8, 0xe7, 0xac, 0x42, 0x05, 0x62, 0xdd, 0x5b, 0xa6, 0x56, 0x5f,
+	0x33, 0x95, 0x29, 0xe6, 0x5e, 0xb3, 0x12, 0x8a, 0x6c, 0xdd, 0x5f, 0xaa, 0x4f, 0xa6, 0x7c, 0x57,
+	0xe7, 0xff, 0x52, 0xf1, 0x9b, 0xff, 0x0d, 0x00, 0xc9, 0xd5, 0x6a, 0x31, 0x65, 0x21, 0x00, 0x00,
 }
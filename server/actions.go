@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// action is something automation can do: set a device or a group to a
+// state, activate a scene, wait or send a notification. It is stored by
+// schedules and rules.
+type action struct {
+	Device     string        `json:"device,omitempty"`
+	Group      string        `json:"group,omitempty"`
+	Scene      string        `json:"scene,omitempty"`
+	State      bool          `json:"state"`
+	Brightness int32         `json:"brightness,omitempty"`
+	Delay      time.Duration `json:"delay,omitempty"`
+	Notify     string        `json:"notify,omitempty"`
+}
+
+// newAction validates an Action from the API. Its device is stored by UDN
+// so it survives renames, and its group or scene must exist.
+func (s *Server) newAction(in *apb.Action) (*action, error) {
+	if in == nil {
+		return nil, fmt.Errorf("an action is required")
+	}
+	if in.DelaySeconds < 0 {
+		return nil, fmt.Errorf("delay must not be negative")
+	}
+	kinds := 0
+	for _, k := range []bool{in.Device != "", in.Group != "", in.Scene != "", in.DelaySeconds > 0, in.Notify != ""} {
+		if k {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return nil, fmt.Errorf("an action needs exactly one of a device, group, scene, delay or notification")
+	}
+
+	a := &action{
+		Group:      rename(in.Group),
+		Scene:      rename(in.Scene),
+		State:      in.State,
+		Brightness: in.Brightness,
+		Delay:      time.Duration(in.DelaySeconds) * time.Second,
+		Notify:     in.Notify,
+	}
+	switch {
+	case in.Device != "":
+		d, err := s.lookupDevice(in.Device)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", in.Device, err)
+		}
+		if d.ReadOnly() {
+			return nil, fmt.Errorf("%s is a sensor and cannot be updated", in.Device)
+		}
+		a.Device = d.UDN
+	case a.Group != "":
+		if _, ok := s.groups.get(a.Group); !ok && s.configGroup(a.Group) == nil {
+			return nil, fmt.Errorf("no group %s found", in.Group)
+		}
+	case a.Scene != "":
+		if _, ok := s.scenes.get(a.Scene); !ok {
+			return nil, fmt.Errorf("no scene %s found", in.Scene)
+		}
+	}
+	return a, nil
+}
+
+func (a *action) api() *apb.Action {
+	return &apb.Action{
+		Device:       a.Device,
+		Group:        a.Group,
+		Scene:        a.Scene,
+		State:        a.State,
+		Brightness:   a.Brightness,
+		DelaySeconds: int64(a.Delay / time.Second),
+		Notify:       a.Notify,
+	}
+}
+
+func (a *action) String() string {
+	switch {
+	case a.Scene != "":
+		return fmt.Sprintf("activate scene %s", a.Scene)
+	case a.Group != "":
+		return fmt.Sprintf("set group %s to %v", a.Group, a.State)
+	case a.Delay > 0:
+		return fmt.Sprintf("wait %v", a.Delay)
+	case a.Notify != "":
+		return fmt.Sprintf("notify %q", a.Notify)
+	}
+	return fmt.Sprintf("set %s to %v", a.Device, a.State)
+}
+
+// run performs an action, using the same retries as UpdateDevice.
+// Actions on several devices fail if any of the devices fails.
+func (s *Server) run(a *action, src source) error {
+	var results []*apb.DeviceResult
+	var err error
+	switch {
+	case a.Delay > 0:
+		time.Sleep(a.Delay)
+		return nil
+	case a.Notify != "":
+		return s.notify(a.Notify)
+	case a.Scene != "":
+		if results, err = s.activateScene(context.Background(), a.Scene, src); err != nil {
+			return err
+		}
+	case a.Group != "":
+		if results, err = s.setGroupState(context.Background(), a.Group, a.State, src); err != nil {
+			return err
+		}
+	default:
+		d, err := s.lookupDevice(a.Device)
+		if err != nil {
+			return err
+		}
+		_, err = s.applyDevice(context.Background(), d, &apb.Device{State: a.State, Brightness: a.Brightness}, src)
+		return err
+	}
+
+	for _, r := range results {
+		if !r.Success {
+			return fmt.Errorf("%s: %s", r.Name, r.Error)
+		}
+	}
+	return nil
+}
+
+// runAll performs actions in order, stopping at the first failure.
+func (s *Server) runAll(actions []*action, src source) error {
+	for _, a := range actions {
+		if err := s.run(a, src); err != nil {
+			return fmt.Errorf("%v: %v", a, err)
+		}
+	}
+	return nil
+}
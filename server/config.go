@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"time"
+
+	"github.com/bamnet/apartment/wemo"
+	"github.com/cenk/backoff"
+	"golang.org/x/net/context"
+	"gopkg.in/yaml.v2"
+)
+
+// config holds the server's settings, read from a YAML file such as:
+//
+//	listen: ":10000"
+//	data_dir: /var/lib/apartment
+//	discovery:
+//	  interval: 2m
+//	  static_hosts: ["192.168.20.14:49153"]
+//	  probe: [192.168.20.0/24]
+//	  probe_interval: 30m
+//	retry:
+//	  max_elapsed_time: 30s
+//	aliases:
+//	  bathroomfan: [fan]
+//	groups:
+//	  kitchen: [cabinetlights, coffee]
+//
+// Everything but the listen addresses and the data directory is reloaded
+// on SIGHUP.
+type config struct {
+	Listen      string `yaml:"listen"`
+	EventListen string `yaml:"event_listen"`
+	DataDir     string `yaml:"data_dir"`
+	ScriptsDir  string `yaml:"scripts_dir"`
+	Location    string `yaml:"location"`
+	Timezone    string `yaml:"timezone"`
+	NotifyURL   string `yaml:"notify_url"`
+
+	Discovery discoveryConfig `yaml:"discovery"`
+	Retry     retryConfig     `yaml:"retry"`
+
+	// Aliases and groups defined here are in addition to those managed
+	// through the API, and cannot be changed through it. Devices are given
+	// by name or UDN.
+	Aliases map[string][]string `yaml:"aliases"`
+	Groups  map[string][]string `yaml:"groups"`
+}
+
+type discoveryConfig struct {
+	Interval time.Duration `yaml:"interval"`
+	// Number of scans in-a-row a device must be not found to be removed.
+	MissingThreshold int `yaml:"missing_threshold"`
+	// UPnP device types to search for.
+	Types []string `yaml:"types"`
+	// Turns off SSDP, for networks multicast does not reach.
+	DisableSSDP bool `yaml:"disable_ssdp"`
+	// host:port addresses of devices SSDP cannot find.
+	StaticHosts []string `yaml:"static_hosts"`
+	// IPv4 networks, such as 192.168.20.0/24, to scan for devices, at most
+	// 1024 addresses each.
+	Probe []string `yaml:"probe"`
+	// How often the probe networks are scanned. Scanning is slow, so this is
+	// longer than the interval of the other discovery methods, and must be
+	// longer than a scan of every network can take.
+	ProbeInterval time.Duration `yaml:"probe_interval"`
+}
+
+// retryConfig tunes the exponential backoff of device calls.
+type retryConfig struct {
+	InitialInterval time.Duration `yaml:"initial_interval"`
+	Multiplier      float64       `yaml:"multiplier"`
+	MaxInterval     time.Duration `yaml:"max_interval"`
+	MaxElapsedTime  time.Duration `yaml:"max_elapsed_time"`
+}
+
+// defaultConfig is the configuration used without a file, and the base a
+// file is read on top of.
+func defaultConfig() *config {
+	b := backoff.NewExponentialBackOff()
+	return &config{
+		Listen:      ":10000",
+		EventListen: ":10001",
+		DataDir:     ".",
+		ScriptsDir:  "scripts",
+		Discovery: discoveryConfig{
+			Interval:         60 * time.Second,
+			MissingThreshold: 5,
+			ProbeInterval:    15 * time.Minute,
+			Types:            append([]string(nil), wemo.DefaultTypes...),
+		},
+		Retry: retryConfig{
+			InitialInterval: b.InitialInterval,
+			Multiplier:      b.Multiplier,
+			MaxInterval:     b.MaxInterval,
+			MaxElapsedTime:  b.MaxElapsedTime,
+		},
+	}
+}
+
+// loadConfig reads the config file at path on top of base.
+// Unknown settings are rejected to catch typos.
+func loadConfig(path string, base *config) (*config, error) {
+	c := *base
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &c, nil
+}
+
+func (c *config) validate() error {
+	if c.Discovery.Interval < time.Second {
+		return fmt.Errorf("discovery interval must be at least 1s")
+	}
+	if c.Discovery.MissingThreshold < 0 {
+		return fmt.Errorf("missing threshold must not be negative")
+	}
+	for _, h := range c.Discovery.StaticHosts {
+		if _, _, err := net.SplitHostPort(h); err != nil {
+			return fmt.Errorf("static host %q must be host:port", h)
+		}
+	}
+	if c.Discovery.ProbeInterval < time.Second {
+		return fmt.Errorf("probe interval must be at least 1s")
+	}
+	var scan time.Duration
+	for _, cidr := range c.Discovery.Probe {
+		t, err := wemo.ProbeTime(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid probe network %q: %v", cidr, err)
+		}
+		scan += t
+	}
+	if scan > c.Discovery.ProbeInterval {
+		return fmt.Errorf("probing takes up to %v, longer than the probe interval of %v", scan, c.Discovery.ProbeInterval)
+	}
+	if c.Retry.InitialInterval <= 0 || c.Retry.MaxInterval < c.Retry.InitialInterval {
+		return fmt.Errorf("retry intervals must be positive, with the maximum above the initial one")
+	}
+	if c.Retry.Multiplier < 1 {
+		return fmt.Errorf("retry multiplier must be at least 1")
+	}
+	seen := map[string]bool{}
+	for _, aliases := range c.Aliases {
+		for _, a := range aliases {
+			if seen[rename(a)] {
+				return fmt.Errorf("alias %s is used more than once", a)
+			}
+			seen[rename(a)] = true
+		}
+	}
+	return nil
+}
+
+// restartNeeded lists the settings which differ between c and next but only
+// take effect after a restart.
+func (c *config) restartNeeded(next *config) []string {
+	changed := []string{}
+	for _, s := range []struct {
+		name      string
+		old, next string
+	}{
+		{"listen", c.Listen, next.Listen},
+		{"event_listen", c.EventListen, next.EventListen},
+		{"data_dir", c.DataDir, next.DataDir},
+	} {
+		if s.old != s.next {
+			changed = append(changed, s.name)
+		}
+	}
+	return changed
+}
+
+// backOff returns a new backoff policy for retrying device calls.
+func (c *config) backOff() *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = c.Retry.InitialInterval
+	b.Multiplier = c.Retry.Multiplier
+	b.MaxInterval = c.Retry.MaxInterval
+	b.MaxElapsedTime = c.Retry.MaxElapsedTime
+	b.Reset()
+	return b
+}
+
+// alias returns the device, by name or UDN, with the given alias.
+func (c *config) alias(name string) (string, bool) {
+	for device, aliases := range c.Aliases {
+		for _, a := range aliases {
+			if rename(a) == name {
+				return device, true
+			}
+		}
+	}
+	return "", false
+}
+
+// aliasesOf returns the aliases of a device.
+func (c *config) aliasesOf(d *wemo.Device) []string {
+	aliases := []string{}
+	for device, as := range c.Aliases {
+		if device == d.UDN || rename(device) == rename(d.FriendlyName) {
+			aliases = append(aliases, as...)
+		}
+	}
+	return aliases
+}
+
+// group returns the devices, by name or UDN, of a group.
+func (c *config) group(name string) ([]string, bool) {
+	for g, devices := range c.Groups {
+		if rename(g) == name {
+			return devices, true
+		}
+	}
+	return nil, false
+}
+
+// backOff returns the backoff policy for device calls made for ctx, giving
+// up by its deadline if it has one.
+func (s *Server) backOff(ctx context.Context) backoff.BackOff {
+	b := s.config().backOff()
+	if deadline, ok := ctx.Deadline(); ok {
+		if left := time.Until(deadline); b.MaxElapsedTime == 0 || left < b.MaxElapsedTime {
+			b.MaxElapsedTime = left
+		}
+	}
+	return b
+}
+
+// config returns the current configuration.
+// It may be called with or without the mutex held.
+func (s *Server) config() *config {
+	return s.cfg.Load().(*config)
+}
+
+// Reload applies a new configuration. Settings which need a restart are
+// logged and otherwise ignored. The scripts are reloaded from a new scripts
+// directory on their next check.
+func (s *Server) Reload(next *config) {
+	cur := s.config()
+	if changed := cur.restartNeeded(next); len(changed) > 0 {
+		log.Printf("restart the server to apply changes to %v", changed)
+	}
+	// Keep the settings in use until a restart, so they are reported again
+	// on the next reload if still different.
+	kept := *next
+	kept.Listen = cur.Listen
+	kept.EventListen = cur.EventListen
+	kept.DataDir = cur.DataDir
+	if next.Location != cur.Location || next.Timezone != cur.Timezone {
+		if err := s.relocate(next.Location, next.Timezone); err != nil {
+			log.Printf("keeping the previous location and timezone: %v", err)
+			kept.Location = cur.Location
+			kept.Timezone = cur.Timezone
+		}
+	}
+	for _, aliases := range next.Aliases {
+		for _, a := range aliases {
+			if udn, ok := s.names.lookup(rename(a)); ok {
+				log.Printf("alias %s in the config is shadowed by the alias set on %s through the API", a, udn)
+			}
+		}
+	}
+	s.cfg.Store(&kept)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSpec is a parsed cron-style schedule with the five standard fields:
+// minute, hour, day of month, month and day of week.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64 // Bit sets of allowed values.
+
+	// Cron matches either day field when both are restricted.
+	domAny, dowAny bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+var (
+	monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	dayNames   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// parseCron parses a schedule such as "45 7 * * mon-fri".
+// Fields accept "*", values, ranges, lists and steps, e.g. "0-30/10,45".
+// Months and days of the week may be given by their three letter names.
+func parseCron(spec string) (*cronSpec, error) {
+	if m, ok := cronMacros[strings.ToLower(strings.TrimSpace(spec))]; ok {
+		spec = m
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q must have 5 fields", spec)
+	}
+
+	c := &cronSpec{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, err
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, err
+	}
+	// Both 0 and 7 are Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// parseCronField parses one field into a bit set of the values it allows.
+// names, if set, are the names of the values starting at min.
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rng = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseCronValue(bounds[1], min, max, names); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				hi = max // "5/10" means every 10 starting at 5.
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, min, max int, names []string) (int, error) {
+	for i, n := range names {
+		if strings.EqualFold(s, n) {
+			return min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("%q is not between %d and %d", s, min, max)
+	}
+	return v, nil
+}
+
+// matches reports whether the schedule fires in the minute of t.
+func (c *cronSpec) matches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0 &&
+		c.hour&(1<<uint(t.Hour())) != 0 &&
+		c.month&(1<<uint(t.Month())) != 0 &&
+		c.matchesDay(t)
+}
+
+// matchesDay reports whether the schedule fires on the day of t.
+func (c *cronSpec) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+// maxCronSearch bounds how far next looks ahead for a schedule that may
+// never fire, such as "0 0 31 2 *".
+const maxCronSearch = 366 * 24 * time.Hour
+
+// next returns the first time after t the schedule fires, or the zero time
+// if it does not fire within a year. Months, days and hours which do not
+// match are skipped whole.
+func (c *cronSpec) next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	end := t.Add(maxCronSearch)
+	for t.Before(end) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, tc := range []struct {
+		spec    string
+		wantErr bool
+	}{
+		{spec: "* * * * *"},
+		{spec: "45 7 * * mon-fri"},
+		{spec: "0-30/10,45 */2 1,15 jan-jun 0"},
+		{spec: "5/10 * * * *"},
+		{spec: "0 0 * * 7"},
+		{spec: "@daily"},
+		{spec: " @Hourly "},
+		{spec: "* * * *", wantErr: true},
+		{spec: "* * * * * *", wantErr: true},
+		{spec: "60 * * * *", wantErr: true},
+		{spec: "* 24 * * *", wantErr: true},
+		{spec: "* * 0 * *", wantErr: true},
+		{spec: "* * * 13 *", wantErr: true},
+		{spec: "* * * * 8", wantErr: true},
+		{spec: "30-10 * * * *", wantErr: true},
+		{spec: "*/0 * * * *", wantErr: true},
+		{spec: "* * * foo *", wantErr: true},
+		{spec: "@yearly", wantErr: true},
+	} {
+		_, err := parseCron(tc.spec)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseCron(%q) error = %v, want error: %v", tc.spec, err, tc.wantErr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.ParseInLocation("2006-01-02 15:04:05", s, ny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	for _, tc := range []struct {
+		spec string
+		from string
+		want string // Empty if the schedule never fires.
+	}{
+		{"* * * * *", "2024-03-01 10:00:30", "2024-03-01 10:01:00"},
+		{"* * * * *", "2024-03-01 10:00:00", "2024-03-01 10:01:00"},
+		{"45 7 * * mon-fri", "2024-03-01 07:45:00", "2024-03-04 07:45:00"},
+		{"45 7 * * mon-fri", "2024-03-04 07:00:00", "2024-03-04 07:45:00"},
+		{"*/15 9-17 * * *", "2024-03-01 17:50:00", "2024-03-02 09:00:00"},
+		{"0 0 1 * *", "2024-12-15 12:00:00", "2025-01-01 00:00:00"},
+		{"0 12 29 2 *", "2024-03-01 00:00:00", ""},
+		{"0 12 29 2 *", "2024-01-01 00:00:00", "2024-02-29 12:00:00"},
+		{"0 0 31 2 *", "2024-01-01 00:00:00", ""},
+		// Either day field matches when both are restricted.
+		{"0 8 13 * fri", "2024-03-01 09:00:00", "2024-03-08 08:00:00"},
+		{"0 8 13 * fri", "2024-03-09 09:00:00", "2024-03-13 08:00:00"},
+		{"@weekly", "2024-03-01 00:00:00", "2024-03-03 00:00:00"},
+		// 02:30 does not exist on the day clocks spring forward.
+		{"30 2 * * *", "2024-03-10 00:00:00", "2024-03-11 02:30:00"},
+		{"30 3 * * *", "2024-03-10 00:00:00", "2024-03-10 03:30:00"},
+	} {
+		c, err := parseCron(tc.spec)
+		if err != nil {
+			t.Fatalf("parseCron(%q): %v", tc.spec, err)
+		}
+		got := c.next(at(tc.from))
+		if tc.want == "" {
+			if !got.IsZero() {
+				t.Errorf("%q next after %s = %v, want never", tc.spec, tc.from, got)
+			}
+			continue
+		}
+		if want := at(tc.want); !got.Equal(want) {
+			t.Errorf("%q next after %s = %v, want %v", tc.spec, tc.from, got, want)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/bamnet/apartment/wemo"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// groups holds named sets of devices, such as rooms, persisted in the store.
+// Members are stored by UDN so they survive devices being renamed.
+type groups struct {
+	store   *store
+	members map[string][]string // Group name to device UDNs.
+
+	mutex *sync.Mutex
+}
+
+// loadGroups reads the groups kept in the store.
+func loadGroups(st *store) (*groups, error) {
+	g := &groups{
+		store:   st,
+		members: map[string][]string{},
+		mutex:   &sync.Mutex{},
+	}
+	if err := st.load("groups", &g.members); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *groups) get(name string) ([]string, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	m, ok := g.members[name]
+	return append([]string(nil), m...), ok
+}
+
+// set stores the members of a group. With create set, the group must not
+// exist yet, otherwise it must already exist.
+func (g *groups) set(name string, members []string, create bool) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if _, ok := g.members[name]; ok == create {
+		if create {
+			return fmt.Errorf("group %s already exists", name)
+		}
+		return fmt.Errorf("no group found")
+	}
+	g.members[name] = members
+	return g.store.save("groups", g.members)
+}
+
+func (g *groups) delete(name string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if _, ok := g.members[name]; !ok {
+		return fmt.Errorf("no group found")
+	}
+	delete(g.members, name)
+	return g.store.save("groups", g.members)
+}
+
+// dropDevice removes a device from every group it is a member of.
+func (g *groups) dropDevice(udn string) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	changed := false
+	for name, m := range g.members {
+		kept := []string{}
+		for _, member := range m {
+			if member != udn {
+				kept = append(kept, member)
+			}
+		}
+		if len(kept) != len(m) {
+			g.members[name] = kept
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return g.store.save("groups", g.members)
+}
+
+func (g *groups) list() []*apb.Group {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	list := []*apb.Group{}
+	for name, m := range g.members {
+		list = append(list, &apb.Group{
+			Name:    name,
+			Devices: append([]string(nil), m...),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// CreateGroup creates a new group of devices.
+// Members may be given by name, alias or UDN, they are returned as UDNs.
+func (s *Server) CreateGroup(ctx context.Context, in *apb.CreateGroupRequest) (*apb.Group, error) {
+	return s.storeGroup(in.Group, true)
+}
+
+// ListGroups lists all the groups.
+// Groups from the config file are included, with the devices which can be
+// found given as UDNs.
+func (s *Server) ListGroups(ctx context.Context, _ *apb.ListGroupsRequest) (*apb.ListGroupsResponse, error) {
+	list := s.groups.list()
+	for name := range s.config().Groups {
+		g := &apb.Group{Name: rename(name)}
+		for udn := range s.configGroup(g.Name) {
+			g.Devices = append(g.Devices, udn)
+		}
+		sort.Strings(g.Devices)
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return &apb.ListGroupsResponse{Group: list}, nil
+}
+
+// UpdateGroup replaces the members of a group.
+func (s *Server) UpdateGroup(ctx context.Context, in *apb.UpdateGroupRequest) (*apb.Group, error) {
+	return s.storeGroup(in.Group, false)
+}
+
+// DeleteGroup deletes a group. Its devices are not affected.
+func (s *Server) DeleteGroup(ctx context.Context, in *apb.DeleteGroupRequest) (*apb.DeleteGroupResponse, error) {
+	if _, ok := s.config().group(rename(in.Name)); ok {
+		return nil, fmt.Errorf("group %s is defined in the config file", in.Name)
+	}
+	if err := s.groups.delete(rename(in.Name)); err != nil {
+		return nil, err
+	}
+	return &apb.DeleteGroupResponse{}, nil
+}
+
+// SetGroupState turns all the devices of a group on or off at once.
+// Each device is reported on separately, a failing device does not stop
+// the others from being set.
+func (s *Server) SetGroupState(ctx context.Context, in *apb.SetGroupStateRequest) (*apb.SetGroupStateResponse, error) {
+	results, err := s.setGroupState(ctx, in.Name, in.State, apiSource(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &apb.SetGroupStateResponse{Result: results}, nil
+}
+
+func (s *Server) setGroupState(ctx context.Context, name string, state bool, src source) ([]*apb.DeviceResult, error) {
+	want := map[string]*apb.Device{}
+	if members, ok := s.groups.get(rename(name)); ok {
+		for _, udn := range members {
+			want[udn] = &apb.Device{State: state}
+		}
+	} else if members := s.configGroup(rename(name)); members != nil {
+		for udn := range members {
+			want[udn] = &apb.Device{State: state}
+		}
+	} else {
+		return nil, fmt.Errorf("no group found")
+	}
+	return s.applyDevices(ctx, want, src), nil
+}
+
+// configGroup resolves the devices of a group from the config file to
+// UDNs. Devices which cannot be found are kept as given, so they are
+// reported on. nil is returned if there is no such group.
+func (s *Server) configGroup(name string) map[string]bool {
+	devices, ok := s.config().group(name)
+	if !ok {
+		return nil
+	}
+	members := map[string]bool{}
+	for _, device := range devices {
+		d, err := s.lookupDevice(device)
+		if err != nil {
+			d, err = s.lookupDevice(rename(device))
+		}
+		if err != nil {
+			members[device] = true
+			continue
+		}
+		members[d.UDN] = true
+	}
+	return members
+}
+
+// applyDevices applies the wanted states to many devices, keyed by UDN,
+// concurrently. A device only succeeds if it reaches the wanted state,
+// except for momentary Makers which cannot be checked. Readings such as
+// energy use are included when they can be read, failing to read them does
+// not fail the device.
+func (s *Server) applyDevices(ctx context.Context, want map[string]*apb.Device, src source) []*apb.DeviceResult {
+	results := []*apb.DeviceResult{}
+	mutex := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for udn, w := range want {
+		wg.Add(1)
+		go func(udn string, w *apb.Device) {
+			defer wg.Done()
+			result := &apb.DeviceResult{Udn: udn}
+			d, err := s.lookupDevice(udn)
+			var state wemo.PowerState
+			if err == nil {
+				result.Name = rename(d.FriendlyName)
+				d, state, err = s.applyState(ctx, d, w, src)
+			}
+			if err == nil {
+				result.Device = s.apiDevice(d, state)
+				detailsErr := s.addDetails(ctx, result.Device, d)
+				if detailsErr != nil {
+					log.Printf("unable to read details of %s: %v", d.FriendlyName, detailsErr)
+				}
+				// The relay of a momentary Maker has opened again by the
+				// time its state is read back.
+				momentary := result.Device.Momentary || (d.IsMaker() && detailsErr != nil)
+				if !momentary && state.IsOn() != w.State {
+					err = fmt.Errorf("state is %v, want %v", state.IsOn(), w.State)
+				}
+			}
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Success = true
+			}
+
+			mutex.Lock()
+			results = append(results, result)
+			mutex.Unlock()
+		}(udn, w)
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+// storeGroup resolves the members of a group to UDNs and saves it.
+func (s *Server) storeGroup(in *apb.Group, create bool) (*apb.Group, error) {
+	if in == nil || in.Name == "" {
+		return nil, fmt.Errorf("a group name is required")
+	}
+	name := rename(in.Name)
+	if _, ok := s.config().group(name); ok {
+		return nil, fmt.Errorf("group %s is defined in the config file", in.Name)
+	}
+
+	members := []string{}
+	for _, m := range in.Devices {
+		d, err := s.lookupDevice(m)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", m, err)
+		}
+		members = append(members, d.UDN)
+	}
+	if err := s.groups.set(name, members, create); err != nil {
+		return nil, err
+	}
+	return &apb.Group{
+		Name:    name,
+		Devices: members,
+	}, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bamnet/apartment/wemo"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+const (
+	defaultHistoryPage = 100
+	maxHistoryPage     = 1000
+
+	// How long after the server changes a device an event reporting the
+	// same state is attributed to the server rather than the device.
+	expectWindow = 10 * time.Second
+)
+
+// source is what changed a device, recorded in its history.
+type source struct {
+	kind   apb.HistoryRecord_Source
+	detail string // Such as the name of a rule or the address of a client.
+}
+
+var (
+	eventSource     = source{kind: apb.HistoryRecord_EVENT}
+	discoverySource = source{kind: apb.HistoryRecord_DISCOVERY}
+	timerSource     = source{kind: apb.HistoryRecord_TIMER}
+)
+
+// apiSource attributes a change to the client of an API call.
+func apiSource(ctx context.Context) source {
+	src := source{kind: apb.HistoryRecord_API}
+	if p, ok := peer.FromContext(ctx); ok {
+		src.detail = p.Addr.String()
+	}
+	return src
+}
+
+// expectation is a change the server made which the device is expected to
+// report through an event.
+type expectation struct {
+	state   bool
+	src     source
+	expires time.Time
+}
+
+// expect notes that src is about to set a device to state, so the event the
+// device sends is attributed to src.
+func (s *Server) expect(udn string, state bool, src source) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.expected[udn] = &expectation{
+		state:   state,
+		src:     src,
+		expires: time.Now().Add(expectWindow),
+	}
+}
+
+// attribute returns the source of a state change reported by src.
+// The caller must hold the mutex.
+func (s *Server) attribute(udn string, state wemo.PowerState, src source) source {
+	e, ok := s.expected[udn]
+	if !ok {
+		return src
+	}
+	if time.Now().After(e.expires) {
+		delete(s.expected, udn)
+		return src
+	}
+	if e.state != state.IsOn() {
+		return src
+	}
+	delete(s.expected, udn)
+	return e.src
+}
+
+// historyRecord is a state change as kept in the store.
+type historyRecord struct {
+	Time     time.Time `json:"time"`
+	UDN      string    `json:"udn"`
+	Name     string    `json:"name"`
+	OldState string    `json:"old_state"`
+	NewState string    `json:"new_state"`
+	Source   string    `json:"source"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// record appends a state change to the history.
+func (st *store) record(r *historyRecord) {
+	data, err := json.Marshal(r)
+	if err == nil {
+		err = st.db.Update(func(tx *bolt.Tx) error {
+			b := tx.Bucket(historyBucket)
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			return b.Put(historyKey(seq), data)
+		})
+	}
+	if err != nil {
+		log.Printf("unable to record history of %s: %v", r.UDN, err)
+	}
+}
+
+// historyKey encodes a sequence number so keys sort in insertion order.
+func historyKey(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
+
+// QueryHistory returns recorded state changes, newest first, optionally
+// filtered by device, time range and source.
+func (s *Server) QueryHistory(ctx context.Context, in *apb.QueryHistoryRequest) (*apb.QueryHistoryResponse, error) {
+	udn := in.Device
+	if in.Device != "" {
+		// Removed devices can still be queried by UDN.
+		if d, err := s.lookupDevice(in.Device); err == nil {
+			udn = d.UDN
+		}
+	}
+	sources := map[string]bool{}
+	for _, src := range in.Sources {
+		sources[src.String()] = true
+	}
+	size := int(in.PageSize)
+	if size <= 0 {
+		size = defaultHistoryPage
+	}
+	if size > maxHistoryPage {
+		size = maxHistoryPage
+	}
+	var start uint64
+	if in.PageToken != "" {
+		var err error
+		if start, err = strconv.ParseUint(in.PageToken, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid page token")
+		}
+	}
+
+	resp := &apb.QueryHistoryResponse{}
+	err := s.store.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(historyBucket).Cursor()
+		k, v := c.Last()
+		if start > 0 {
+			k, v = c.Seek(historyKey(start))
+			if k == nil || binary.BigEndian.Uint64(k) != start {
+				k, v = c.Prev()
+			}
+		}
+		for ; k != nil; k, v = c.Prev() {
+			if len(resp.Record) == size {
+				resp.NextPageToken = strconv.FormatUint(binary.BigEndian.Uint64(k), 10)
+				return nil
+			}
+			r := &historyRecord{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return err
+			}
+			if in.StartTime > 0 && r.Time.Unix() < in.StartTime {
+				return nil // Records are in time order, nothing older matches.
+			}
+			if (in.EndTime > 0 && r.Time.Unix() >= in.EndTime) ||
+				(udn != "" && r.UDN != udn) ||
+				(len(sources) > 0 && !sources[r.Source]) {
+				continue
+			}
+			resp.Record = append(resp.Record, &apb.HistoryRecord{
+				Time:     r.Time.Unix(),
+				Udn:      r.UDN,
+				Name:     r.Name,
+				OldState: apb.PowerState(apb.PowerState_value[r.OldState]),
+				NewState: apb.PowerState(apb.PowerState_value[r.NewState]),
+				Source:   apb.HistoryRecord_Source(apb.HistoryRecord_Source_value[r.Source]),
+				Detail:   r.Detail,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
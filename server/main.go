@@ -1,25 +1,95 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"google.golang.org/grpc"
 
 	apb "github.com/bamnet/apartment/proto/apartment"
 )
 
+var (
+	configPath = flag.String("config", "", "YAML config file, reloaded on SIGHUP. Its settings take precedence over flags.")
+	eventAddr  = flag.String("event_addr", ":10001", "Address to receive WeMo event notifications on.")
+	dataDir    = flag.String("data_dir", ".", "Directory to persist aliases, groups and other server state in.")
+	coords     = flag.String("location", "", "Latitude,longitude of the apartment, used to compute sunrise and sunset.")
+	timezone   = flag.String("timezone", "", "IANA timezone of schedules, defaults to the local timezone.")
+	scriptsDir = flag.String("scripts_dir", "scripts", "Directory of Starlark automation scripts, reloaded when they change.")
+	static     = flag.String("static_hosts", "", "Comma separated host:port addresses of devices SSDP discovery cannot find.")
+	probe      = flag.String("probe", "", "Comma separated IPv4 networks to scan for devices, for when SSDP multicast is unavailable.")
+	notifyURL  = flag.String("notify_url", "", "URL rule notifications are POSTed to as plain text. Notifications are only logged if unset.")
+)
+
+// flagConfig is the configuration given by flags.
+func flagConfig() *config {
+	c := defaultConfig()
+	c.EventListen = *eventAddr
+	c.DataDir = *dataDir
+	c.Location = *coords
+	c.Timezone = *timezone
+	c.ScriptsDir = *scriptsDir
+	c.NotifyURL = *notifyURL
+	c.Discovery.StaticHosts = splitList(*static)
+	c.Discovery.Probe = splitList(*probe)
+	return c
+}
+
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func readConfig() (*config, error) {
+	if *configPath == "" {
+		c := flagConfig()
+		return c, c.validate()
+	}
+	return loadConfig(*configPath, flagConfig())
+}
+
 func main() {
-	lis, err := net.Listen("tcp", ":10000")
+	flag.Parse()
+	cfg, err := readConfig()
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", cfg.Listen)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
 	srv := grpc.NewServer()
-	aSrv, err := NewServer()
+	aSrv, err := NewServer(cfg)
 	if err != nil {
 		log.Fatalf("unable to setup apartment server: %v", err)
 	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			cfg, err := readConfig()
+			if err != nil {
+				log.Printf("not reloading config: %v", err)
+				continue
+			}
+			aSrv.Reload(cfg)
+			log.Printf("reloaded config")
+		}
+	}()
+
 	apb.RegisterApartmentServer(srv, aSrv)
 	srv.Serve(lis)
 }
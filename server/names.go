@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// names is the server's own naming layer, independent of the names stored
+// on the devices. It maps device UDNs to a display name and aliases and is
+// persisted in the store.
+type names struct {
+	store   *store
+	devices map[string]*deviceNames
+
+	mutex *sync.Mutex
+}
+
+type deviceNames struct {
+	DisplayName string   `json:"display_name,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+// loadNames reads the names kept in the store.
+func loadNames(st *store) (*names, error) {
+	n := &names{
+		store:   st,
+		devices: map[string]*deviceNames{},
+		mutex:   &sync.Mutex{},
+	}
+	if err := st.load("names", &n.devices); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// get returns the display name and aliases of a device.
+func (n *names) get(udn string) (string, []string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	dn, ok := n.devices[udn]
+	if !ok {
+		return "", nil
+	}
+	return dn.DisplayName, append([]string(nil), dn.Aliases...)
+}
+
+// lookup finds the UDN of the device with the given alias.
+func (n *names) lookup(alias string) (string, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for udn, dn := range n.devices {
+		for _, a := range dn.Aliases {
+			if rename(a) == alias {
+				return udn, true
+			}
+		}
+	}
+	return "", false
+}
+
+// forget drops the display name and aliases of a device.
+func (n *names) forget(udn string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if _, ok := n.devices[udn]; !ok {
+		return nil
+	}
+	delete(n.devices, udn)
+	return n.store.save("names", n.devices)
+}
+
+// set replaces the display name and aliases of a device and saves them.
+// An alias already used by another device is rejected.
+func (n *names) set(udn, displayName string, aliases []string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for other, dn := range n.devices {
+		if other == udn {
+			continue
+		}
+		for _, a := range dn.Aliases {
+			for _, b := range aliases {
+				if rename(a) == rename(b) {
+					return fmt.Errorf("alias %s is already used by %s", b, other)
+				}
+			}
+		}
+	}
+
+	if displayName == "" && len(aliases) == 0 {
+		delete(n.devices, udn)
+	} else {
+		n.devices[udn] = &deviceNames{
+			DisplayName: displayName,
+			Aliases:     aliases,
+		}
+	}
+	return n.store.save("names", n.devices)
+}
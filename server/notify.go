@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var notifyClient = &http.Client{Timeout: 10 * time.Second}
+
+// notify sends a message to the configured notification URL as a plain
+// text POST, which works with services like ntfy or a chat webhook.
+// Without a URL the message is only logged.
+func (s *Server) notify(msg string) error {
+	log.Printf("notification: %s", msg)
+	url := s.config().NotifyURL
+	if url == "" {
+		return nil
+	}
+	resp, err := notifyClient.Post(url, "text/plain; charset=utf-8", strings.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notification failed: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/bamnet/apartment/wemo"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// registry tracks the devices added and forgotten by hand, persisted in the
+// store. Added devices are pinned: their hosts are probed on every
+// discovery pass and they are never dropped for going missing. Forgotten
+// devices are ignored by discovery until they are added again.
+type registry struct {
+	store *store
+	state registryState
+
+	mutex *sync.Mutex
+}
+
+type registryState struct {
+	Pinned    map[string]string `json:"pinned"`    // UDN to host.
+	Forgotten map[string]bool   `json:"forgotten"` // UDNs.
+}
+
+// loadRegistry reads the registry kept in the store.
+func loadRegistry(st *store) (*registry, error) {
+	r := &registry{
+		store: st,
+		state: registryState{
+			Pinned:    map[string]string{},
+			Forgotten: map[string]bool{},
+		},
+		mutex: &sync.Mutex{},
+	}
+	if err := st.load("registry", &r.state); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *registry) save() error {
+	return r.store.save("registry", r.state)
+}
+
+// hosts returns the hosts of the pinned devices.
+func (r *registry) hosts() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	seen := map[string]bool{}
+	hosts := []string{}
+	for _, h := range r.state.Pinned {
+		if !seen[h] {
+			seen[h] = true
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+func (r *registry) pinned(udn string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	_, ok := r.state.Pinned[udn]
+	return ok
+}
+
+func (r *registry) forgotten(udn string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.state.Forgotten[udn]
+}
+
+// pin adds devices by hand, reversing any earlier forget.
+func (r *registry) pin(devices []*wemo.Device) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, d := range devices {
+		r.state.Pinned[d.UDN] = d.Host
+		delete(r.state.Forgotten, d.UDN)
+	}
+	return r.save()
+}
+
+// forget unpins a device and stops it from being discovered.
+func (r *registry) forget(udn string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.state.Pinned, udn)
+	r.state.Forgotten[udn] = true
+	return r.save()
+}
+
+// moved updates the host of a pinned device which moved. It is called with
+// the server mutex held, so the registry is saved in the background.
+func (r *registry) moved(d *wemo.Device) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if h, ok := r.state.Pinned[d.UDN]; !ok || h == d.Host {
+		return
+	}
+	r.state.Pinned[d.UDN] = d.Host
+	go func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		if err := r.save(); err != nil {
+			log.Printf("unable to save registry: %v", err)
+		}
+	}()
+}
+
+// AddDevice registers the device at a host:port address, for devices which
+// discovery does not find. The device is kept across restarts until it is
+// forgotten. Adding a WeMo Link bridge adds its bulbs.
+func (s *Server) AddDevice(ctx context.Context, in *apb.AddDeviceRequest) (*apb.AddDeviceResponse, error) {
+	if _, _, err := net.SplitHostPort(in.Host); err != nil {
+		return nil, fmt.Errorf("host must be host:port, e.g. 192.168.1.20:49153")
+	}
+	d, err := wemo.NewDevice(in.Host)
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach a device at %s: %v", in.Host, err)
+	}
+	devices := []*wemo.Device{d}
+	if d.DeviceType == wemo.BridgeType {
+		if devices, err = d.Bulbs(); err != nil {
+			return nil, fmt.Errorf("unable to list bulbs of %s: %v", in.Host, err)
+		}
+	}
+	if err := s.registry.pin(devices); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	resp := &apb.AddDeviceResponse{}
+	for _, d := range devices {
+		s.register(d)
+		resp.Device = append(resp.Device, s.apiDevice(s.devices[d.UDN], s.states[d.UDN]))
+	}
+	return resp, nil
+}
+
+// ForgetDevice removes a device, such as a retired plug, right away. It is
+// not rediscovered until it is added again with AddDevice.
+// The device is dropped from its groups and scenes, and its names and timer
+// are removed. A device still used by rules, schedules or the config file
+// cannot be forgotten, those need changing first.
+func (s *Server) ForgetDevice(ctx context.Context, in *apb.ForgetDeviceRequest) (*apb.ForgetDeviceResponse, error) {
+	d, err := s.lookupDevice(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if refs := s.references(d); len(refs) > 0 {
+		return nil, fmt.Errorf("%s is used by %s", rename(d.FriendlyName), strings.Join(refs, ", "))
+	}
+	if err := s.registry.forget(d.UDN); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	if _, ok := s.devices[d.UDN]; ok {
+		s.remove(d.UDN)
+	}
+	s.mutex.Unlock()
+
+	if _, err := s.timers.cancel(d.UDN); err != nil {
+		return nil, err
+	}
+	if err := s.names.forget(d.UDN); err != nil {
+		return nil, err
+	}
+	if err := s.groups.dropDevice(d.UDN); err != nil {
+		return nil, err
+	}
+	if err := s.scenes.dropDevice(d.UDN); err != nil {
+		return nil, err
+	}
+	return &apb.ForgetDeviceResponse{}, nil
+}
+
+// references lists the rules, schedules and config settings which use a
+// device, by UDN or by any of its names.
+func (s *Server) references(d *wemo.Device) []string {
+	uses := func(name string) bool {
+		if name == "" {
+			return false
+		}
+		found, err := s.lookupDevice(name)
+		return err == nil && found.UDN == d.UDN
+	}
+
+	// Collect the device names used first, lookups take the server mutex
+	// which must not be taken while holding the others.
+	used := map[string][]string{} // Reference to the device names it uses.
+	s.rules.mutex.Lock()
+	for name, ru := range s.rules.rules {
+		ref := "rule " + name
+		used[ref] = append(used[ref], ru.Trigger.Device)
+		for _, c := range ru.Conditions {
+			used[ref] = append(used[ref], c.Device)
+		}
+		for _, a := range ru.Actions {
+			used[ref] = append(used[ref], a.Device)
+		}
+	}
+	s.rules.mutex.Unlock()
+	s.schedules.mutex.Lock()
+	for name, sch := range s.schedules.schedules {
+		ref := "schedule " + name
+		used[ref] = append(used[ref], sch.Action.Device)
+	}
+	s.schedules.mutex.Unlock()
+	cfg := s.config()
+	for device := range cfg.Aliases {
+		used["config aliases"] = append(used["config aliases"], device)
+	}
+	for name, devices := range cfg.Groups {
+		ref := "config group " + name
+		used[ref] = append(used[ref], devices...)
+	}
+
+	refs := []string{}
+	for ref, names := range used {
+		for _, name := range names {
+			if uses(name) {
+				refs = append(refs, ref)
+				break
+			}
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
@@ -0,0 +1,467 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/bamnet/apartment/wemo"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// How often power readings and Maker sensors are polled for rule triggers,
+// neither is reported by events.
+const rulePollInterval = 30 * time.Second
+
+// rules holds the automation rules, persisted in the store.
+type rules struct {
+	store *store
+	rules map[string]*rule
+
+	// Last observed value of polled triggers, keyed by rule name, so rules
+	// only fire when the value crosses over.
+	polled map[string]bool
+
+	mutex *sync.Mutex
+}
+
+// rule runs its actions when its trigger fires and all its conditions hold.
+type rule struct {
+	Disabled   bool             `json:"disabled,omitempty"`
+	Trigger    *ruleTrigger     `json:"trigger"`
+	Conditions []*ruleCondition `json:"conditions,omitempty"`
+	Actions    []*action        `json:"actions"`
+	LastFired  time.Time        `json:"last_fired"`
+}
+
+type ruleTrigger struct {
+	Type        string `json:"type"`
+	Device      string `json:"device,omitempty"` // UDN.
+	State       bool   `json:"state,omitempty"`
+	Spec        string `json:"spec,omitempty"`
+	ThresholdMW int64  `json:"threshold_mw,omitempty"`
+
+	at trigger
+}
+
+type ruleCondition struct {
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+	Device string `json:"device,omitempty"` // UDN.
+	State  bool   `json:"state,omitempty"`
+}
+
+// loadRules reads the rules kept in the store.
+func loadRules(st *store, loc *location) (*rules, error) {
+	r := &rules{
+		store:  st,
+		rules:  map[string]*rule{},
+		polled: map[string]bool{},
+		mutex:  &sync.Mutex{},
+	}
+	if err := st.load("rules", &r.rules); err != nil {
+		return nil, err
+	}
+	for name, ru := range r.rules {
+		if ru.Trigger.Type != apb.Trigger_TIME.String() {
+			continue
+		}
+		var err error
+		if ru.Trigger.at, err = parseTrigger(ru.Trigger.Spec, loc); err != nil {
+			return nil, fmt.Errorf("rule %s: %v", name, err)
+		}
+	}
+	return r, nil
+}
+
+// matching returns the names of the enabled rules with a trigger accepted
+// by match.
+func (r *rules) matching(match func(*ruleTrigger) bool) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	names := []string{}
+	for name, ru := range r.rules {
+		if !ru.Disabled && match(ru.Trigger) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// crossed records a polled value for a rule, reporting whether it changed
+// to the value the rule triggers on. The first value seen never fires.
+func (r *rules) crossed(name string, value bool) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	old, seen := r.polled[name]
+	r.polled[name] = value
+	return seen && !old && value
+}
+
+func apiRule(name string, ru *rule) *apb.Rule {
+	rule := &apb.Rule{
+		Name:     name,
+		Disabled: ru.Disabled,
+		Trigger: &apb.Trigger{
+			Type:        apb.Trigger_Type(apb.Trigger_Type_value[ru.Trigger.Type]),
+			Device:      ru.Trigger.Device,
+			State:       ru.Trigger.State,
+			Spec:        ru.Trigger.Spec,
+			ThresholdMw: ru.Trigger.ThresholdMW,
+		},
+	}
+	for _, c := range ru.Conditions {
+		rule.Conditions = append(rule.Conditions, &apb.Condition{
+			After:  c.After,
+			Before: c.Before,
+			Device: c.Device,
+			State:  c.State,
+		})
+	}
+	for _, a := range ru.Actions {
+		rule.Actions = append(rule.Actions, a.api())
+	}
+	if !ru.LastFired.IsZero() {
+		rule.LastFired = ru.LastFired.Unix()
+	}
+	return rule
+}
+
+// newRule validates a Rule from the API, resolving devices to UDNs.
+func (s *Server) newRule(in *apb.Rule) (*rule, error) {
+	if in.Trigger == nil {
+		return nil, fmt.Errorf("a trigger is required")
+	}
+	if len(in.Actions) == 0 {
+		return nil, fmt.Errorf("a rule needs at least one action")
+	}
+	ru := &rule{
+		Disabled: in.Disabled,
+		Trigger: &ruleTrigger{
+			Type:        in.Trigger.Type.String(),
+			State:       in.Trigger.State,
+			ThresholdMW: in.Trigger.ThresholdMw,
+		},
+	}
+
+	if in.Trigger.Type == apb.Trigger_TIME {
+		var err error
+		if ru.Trigger.at, err = parseTrigger(in.Trigger.Spec, s.location()); err != nil {
+			return nil, err
+		}
+		ru.Trigger.Spec = in.Trigger.Spec
+	} else {
+		d, err := s.lookupDevice(in.Trigger.Device)
+		if err != nil {
+			return nil, fmt.Errorf("trigger: %v", err)
+		}
+		ru.Trigger.Device = d.UDN
+		switch in.Trigger.Type {
+		case apb.Trigger_SENSOR:
+			if d.DeviceType != wemo.MotionType && !d.IsMaker() {
+				return nil, fmt.Errorf("%s has no sensor", in.Trigger.Device)
+			}
+		case apb.Trigger_POWER_ABOVE, apb.Trigger_POWER_BELOW:
+			if d.DeviceType != wemo.InsightType {
+				return nil, fmt.Errorf("%s has no energy meter", in.Trigger.Device)
+			}
+		}
+	}
+
+	for _, c := range in.Conditions {
+		cond := &ruleCondition{State: c.State}
+		switch {
+		case c.Device != "":
+			d, err := s.lookupDevice(c.Device)
+			if err != nil {
+				return nil, fmt.Errorf("condition: %v", err)
+			}
+			cond.Device = d.UDN
+		case c.After != "" || c.Before != "":
+			for _, t := range []string{c.After, c.Before} {
+				if _, err := time.Parse("15:04", t); err != nil {
+					return nil, fmt.Errorf("condition times must be HH:MM, got %q", t)
+				}
+			}
+			cond.After, cond.Before = c.After, c.Before
+		default:
+			return nil, fmt.Errorf("a condition needs a device or a time window")
+		}
+		ru.Conditions = append(ru.Conditions, cond)
+	}
+
+	for _, a := range in.Actions {
+		act, err := s.newAction(a)
+		if err != nil {
+			return nil, err
+		}
+		ru.Actions = append(ru.Actions, act)
+	}
+	return ru, nil
+}
+
+// CreateRule adds an automation rule.
+// Devices may be given by name, alias or UDN, they are returned as UDNs.
+func (s *Server) CreateRule(ctx context.Context, in *apb.CreateRuleRequest) (*apb.Rule, error) {
+	return s.storeRule(in.Rule, true)
+}
+
+// GetRule gets a rule by name.
+func (s *Server) GetRule(ctx context.Context, in *apb.GetRuleRequest) (*apb.Rule, error) {
+	name := rename(in.Name)
+	s.rules.mutex.Lock()
+	defer s.rules.mutex.Unlock()
+	ru, ok := s.rules.rules[name]
+	if !ok {
+		return nil, fmt.Errorf("no rule found")
+	}
+	return apiRule(name, ru), nil
+}
+
+// ListRules lists all the rules.
+func (s *Server) ListRules(ctx context.Context, _ *apb.ListRulesRequest) (*apb.ListRulesResponse, error) {
+	s.rules.mutex.Lock()
+	defer s.rules.mutex.Unlock()
+	list := []*apb.Rule{}
+	for name, ru := range s.rules.rules {
+		list = append(list, apiRule(name, ru))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return &apb.ListRulesResponse{Rule: list}, nil
+}
+
+// UpdateRule replaces an existing rule.
+func (s *Server) UpdateRule(ctx context.Context, in *apb.UpdateRuleRequest) (*apb.Rule, error) {
+	return s.storeRule(in.Rule, false)
+}
+
+// DeleteRule deletes a rule.
+func (s *Server) DeleteRule(ctx context.Context, in *apb.DeleteRuleRequest) (*apb.DeleteRuleResponse, error) {
+	name := rename(in.Name)
+	s.rules.mutex.Lock()
+	defer s.rules.mutex.Unlock()
+	if _, ok := s.rules.rules[name]; !ok {
+		return nil, fmt.Errorf("no rule found")
+	}
+	delete(s.rules.rules, name)
+	delete(s.rules.polled, name)
+	if err := s.rules.store.save("rules", s.rules.rules); err != nil {
+		return nil, err
+	}
+	return &apb.DeleteRuleResponse{}, nil
+}
+
+// storeRule validates and saves a rule. With create set, the rule must not
+// exist yet, otherwise it must already exist.
+func (s *Server) storeRule(in *apb.Rule, create bool) (*apb.Rule, error) {
+	if in == nil || in.Name == "" {
+		return nil, fmt.Errorf("a rule name is required")
+	}
+	name := rename(in.Name)
+	ru, err := s.newRule(in)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rules.mutex.Lock()
+	defer s.rules.mutex.Unlock()
+	old, ok := s.rules.rules[name]
+	if ok == create {
+		if create {
+			return nil, fmt.Errorf("rule %s already exists", name)
+		}
+		return nil, fmt.Errorf("no rule found")
+	}
+	if ok {
+		ru.LastFired = old.LastFired
+	}
+	s.rules.rules[name] = ru
+	delete(s.rules.polled, name)
+	if err := s.rules.store.save("rules", s.rules.rules); err != nil {
+		return nil, err
+	}
+	return apiRule(name, ru), nil
+}
+
+// ruleEngine evaluates the rules: device events are watched for state and
+// motion triggers, time triggers are checked every minute and power and
+// Maker sensor triggers are polled.
+func (s *Server) ruleEngine() {
+	events, _ := s.watch()
+	go func() {
+		// Whether each device was last seen on, keyed by UDN. A device is
+		// only considered turned on or off when this was known before and
+		// differs, not when its state is first learned or when it flips
+		// between ON and STANDBY.
+		on := map[string]bool{}
+		s.mutex.Lock()
+		for udn, state := range s.lastKnown {
+			on[udn] = state.IsOn()
+		}
+		for udn, state := range s.states {
+			on[udn] = state.IsOn()
+		}
+		s.mutex.Unlock()
+		for e := range events {
+			udn := e.Device.Udn
+			if e.Type == apb.DeviceEvent_REMOVED {
+				delete(on, udn)
+			}
+			if e.Type != apb.DeviceEvent_STATE_CHANGED || e.Device.PowerState == apb.PowerState_UNKNOWN {
+				continue
+			}
+			was, known := on[udn]
+			on[udn] = e.Device.State
+			if !known || was == e.Device.State {
+				continue
+			}
+			for _, name := range s.rules.matching(func(t *ruleTrigger) bool {
+				switch t.Type {
+				case apb.Trigger_DEVICE_STATE.String():
+				case apb.Trigger_SENSOR.String():
+					if e.Device.Type != apb.Device_MOTION {
+						return false
+					}
+				default:
+					return false
+				}
+				return t.Device == udn && t.State == e.Device.State
+			}) {
+				go s.fire(name)
+			}
+		}
+	}()
+
+	go func() {
+		last := time.Now().In(s.location().tz)
+		for {
+			time.Sleep(last.Truncate(time.Minute).Add(time.Minute).Sub(time.Now()))
+			now := time.Now().In(s.location().tz)
+			for _, name := range s.rules.matching(func(t *ruleTrigger) bool {
+				if t.at == nil {
+					return false
+				}
+				next := t.at.next(last)
+				return !next.IsZero() && !next.After(now)
+			}) {
+				go s.fire(name)
+			}
+			last = now
+		}
+	}()
+
+	go func() {
+		for range time.Tick(rulePollInterval) {
+			s.pollRules()
+		}
+	}()
+}
+
+// pollRules reads the power and sensor values polled triggers depend on and
+// fires the rules whose value crossed over.
+func (s *Server) pollRules() {
+	names := s.rules.matching(func(t *ruleTrigger) bool {
+		switch t.Type {
+		case apb.Trigger_POWER_ABOVE.String(), apb.Trigger_POWER_BELOW.String(), apb.Trigger_SENSOR.String():
+			return true
+		}
+		return false
+	})
+
+	for _, name := range names {
+		s.rules.mutex.Lock()
+		ru, ok := s.rules.rules[name]
+		s.rules.mutex.Unlock()
+		if !ok {
+			continue
+		}
+		t := ru.Trigger
+		s.mutex.Lock()
+		d, ok := s.devices[t.Device]
+		s.mutex.Unlock()
+		if !ok {
+			continue
+		}
+
+		var value bool
+		switch t.Type {
+		case apb.Trigger_SENSOR.String():
+			if !d.IsMaker() {
+				continue // Motion sensors report events.
+			}
+			attrs, err := d.MakerAttributes()
+			if err != nil {
+				log.Printf("rule %s: unable to read sensor: %v", name, err)
+				continue
+			}
+			value = attrs.SensorTriggered == t.State
+		default:
+			p, err := d.InsightParams()
+			if err != nil {
+				log.Printf("rule %s: unable to read power: %v", name, err)
+				continue
+			}
+			mw := int64(p.CurrentPower)
+			value = mw > t.ThresholdMW
+			if t.Type == apb.Trigger_POWER_BELOW.String() {
+				value = mw < t.ThresholdMW
+			}
+		}
+		if s.rules.crossed(name, value) {
+			go s.fire(name)
+		}
+	}
+}
+
+// fire runs the actions of a rule if its conditions hold.
+func (s *Server) fire(name string) {
+	s.rules.mutex.Lock()
+	ru, ok := s.rules.rules[name]
+	s.rules.mutex.Unlock()
+	if !ok || ru.Disabled {
+		return
+	}
+	for _, c := range ru.Conditions {
+		if ok, err := s.holds(c); err != nil || !ok {
+			if err != nil {
+				log.Printf("rule %s: unable to check condition: %v", name, err)
+			}
+			return
+		}
+	}
+
+	log.Printf("rule %s fired", name)
+	s.rules.mutex.Lock()
+	ru.LastFired = time.Now()
+	if err := s.rules.store.save("rules", s.rules.rules); err != nil {
+		log.Printf("unable to save rules: %v", err)
+	}
+	s.rules.mutex.Unlock()
+
+	if err := s.runAll(ru.Actions, source{kind: apb.HistoryRecord_RULE, detail: name}); err != nil {
+		log.Printf("rule %s failed: %v", name, err)
+	}
+}
+
+// holds checks a rule condition.
+func (s *Server) holds(c *ruleCondition) (bool, error) {
+	if c.Device != "" {
+		device, err := s.GetDevice(context.Background(), &apb.GetDeviceRequest{Name: c.Device})
+		if err != nil {
+			return false, err
+		}
+		return device.State == c.State, nil
+	}
+
+	now := time.Now().In(s.location().tz).Format("15:04")
+	if c.After <= c.Before {
+		return c.After <= now && now < c.Before, nil
+	}
+	// The window wraps around midnight, e.g. 22:00 to 06:00.
+	return now >= c.After || now < c.Before, nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// scenes holds named snapshots of device states, persisted in the store.
+type scenes struct {
+	store  *store
+	scenes map[string]map[string]*sceneState // Scene name to states by UDN.
+
+	mutex *sync.Mutex
+}
+
+type sceneState struct {
+	State      bool  `json:"state"`
+	Brightness int32 `json:"brightness,omitempty"`
+}
+
+// loadScenes reads the scenes kept in the store.
+func loadScenes(st *store) (*scenes, error) {
+	sc := &scenes{
+		store:  st,
+		scenes: map[string]map[string]*sceneState{},
+		mutex:  &sync.Mutex{},
+	}
+	if err := st.load("scenes", &sc.scenes); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// get returns a copy of the states of a scene.
+func (sc *scenes) get(name string) (map[string]*sceneState, bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	states, ok := sc.scenes[name]
+	if !ok {
+		return nil, false
+	}
+	copied := map[string]*sceneState{}
+	for udn, st := range states {
+		st := *st
+		copied[udn] = &st
+	}
+	return copied, true
+}
+
+// set stores a scene, replacing any existing scene with the same name.
+func (sc *scenes) set(name string, states map[string]*sceneState) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.scenes[name] = states
+	return sc.store.save("scenes", sc.scenes)
+}
+
+func (sc *scenes) delete(name string) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if _, ok := sc.scenes[name]; !ok {
+		return fmt.Errorf("no scene found")
+	}
+	delete(sc.scenes, name)
+	return sc.store.save("scenes", sc.scenes)
+}
+
+// dropDevice removes a device from every scene.
+func (sc *scenes) dropDevice(udn string) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	changed := false
+	for _, states := range sc.scenes {
+		if _, ok := states[udn]; ok {
+			delete(states, udn)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return sc.store.save("scenes", sc.scenes)
+}
+
+func (sc *scenes) list() []*apb.Scene {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	list := []*apb.Scene{}
+	for name, states := range sc.scenes {
+		list = append(list, apiScene(name, states))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func apiScene(name string, states map[string]*sceneState) *apb.Scene {
+	scene := &apb.Scene{Name: name}
+	for udn, st := range states {
+		scene.Devices = append(scene.Devices, &apb.SceneDevice{
+			Udn:        udn,
+			State:      st.State,
+			Brightness: st.Brightness,
+		})
+	}
+	sort.Slice(scene.Devices, func(i, j int) bool { return scene.Devices[i].Udn < scene.Devices[j].Udn })
+	return scene
+}
+
+// CreateScene captures the current state of some devices under a name.
+// The brightness of dimmable devices is captured too. An existing scene
+// with the same name is replaced.
+func (s *Server) CreateScene(ctx context.Context, in *apb.CreateSceneRequest) (*apb.Scene, error) {
+	if in.Name == "" {
+		return nil, fmt.Errorf("a scene name is required")
+	}
+	if len(in.Devices) == 0 {
+		return nil, fmt.Errorf("a scene needs at least one device")
+	}
+	name := rename(in.Name)
+
+	states := map[string]*sceneState{}
+	for _, m := range in.Devices {
+		device, err := s.GetDevice(ctx, &apb.GetDeviceRequest{Name: m})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", m, err)
+		}
+		if device.ReadOnly {
+			return nil, fmt.Errorf("%s is a sensor and cannot be part of a scene", m)
+		}
+		st := &sceneState{State: device.State}
+		if device.Dimmable && device.State {
+			st.Brightness = device.Brightness
+		}
+		states[device.Udn] = st
+	}
+	if err := s.scenes.set(name, states); err != nil {
+		return nil, err
+	}
+	return apiScene(name, states), nil
+}
+
+// ListScenes lists all the scenes.
+func (s *Server) ListScenes(ctx context.Context, _ *apb.ListScenesRequest) (*apb.ListScenesResponse, error) {
+	return &apb.ListScenesResponse{Scene: s.scenes.list()}, nil
+}
+
+// DeleteScene deletes a scene.
+func (s *Server) DeleteScene(ctx context.Context, in *apb.DeleteSceneRequest) (*apb.DeleteSceneResponse, error) {
+	if err := s.scenes.delete(rename(in.Name)); err != nil {
+		return nil, err
+	}
+	return &apb.DeleteSceneResponse{}, nil
+}
+
+// ActivateScene applies the states captured in a scene to its devices.
+// Each device is reported on separately.
+func (s *Server) ActivateScene(ctx context.Context, in *apb.ActivateSceneRequest) (*apb.ActivateSceneResponse, error) {
+	results, err := s.activateScene(ctx, in.Name, apiSource(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &apb.ActivateSceneResponse{Result: results}, nil
+}
+
+func (s *Server) activateScene(ctx context.Context, name string, src source) ([]*apb.DeviceResult, error) {
+	states, ok := s.scenes.get(rename(name))
+	if !ok {
+		return nil, fmt.Errorf("no scene found")
+	}
+
+	want := map[string]*apb.Device{}
+	for udn, st := range states {
+		want[udn] = &apb.Device{
+			State:      st.State,
+			Brightness: st.Brightness,
+		}
+	}
+	return s.applyDevices(ctx, want, src), nil
+}
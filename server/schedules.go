@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// schedules holds the cron-style schedules, persisted in the store.
+type schedules struct {
+	store     *store
+	schedules map[string]*schedule
+
+	mutex *sync.Mutex
+}
+
+type schedule struct {
+	Spec    string  `json:"spec"`
+	Action  *action `json:"action"`
+	CatchUp string  `json:"catch_up,omitempty"`
+
+	// Checked is the time up to which runs have been handled, runs between
+	// it and now were missed while the server was down.
+	Checked time.Time `json:"checked"`
+	LastRun time.Time `json:"last_run"`
+
+	trigger trigger
+}
+
+// trigger computes when a schedule fires.
+type trigger interface {
+	// next returns the first time after t the schedule fires, or the zero
+	// time if it does not fire within a year.
+	next(t time.Time) time.Time
+}
+
+// parseTrigger parses a cron-style spec or one relative to sunrise or
+// sunset, such as "@sunset -30m".
+func parseTrigger(spec string, loc *location) (trigger, error) {
+	if f := strings.Fields(strings.ToLower(spec)); len(f) > 0 && (f[0] == "@"+sunrise || f[0] == "@"+sunset) {
+		return parseSolar(spec, loc)
+	}
+	return parseCron(spec)
+}
+
+// loadSchedules reads the schedules kept in the store.
+func loadSchedules(st *store, loc *location) (*schedules, error) {
+	sc := &schedules{
+		store:     st,
+		schedules: map[string]*schedule{},
+		mutex:     &sync.Mutex{},
+	}
+	if err := st.load("schedules", &sc.schedules); err != nil {
+		return nil, err
+	}
+	for name, s := range sc.schedules {
+		var err error
+		if s.trigger, err = parseTrigger(s.Spec, loc); err != nil {
+			return nil, fmt.Errorf("schedule %s: %v", name, err)
+		}
+	}
+	return sc, nil
+}
+
+func (sc *schedules) api(name string, s *schedule) *apb.Schedule {
+	schedule := &apb.Schedule{
+		Name:    name,
+		Spec:    s.Spec,
+		Action:  s.Action.api(),
+		CatchUp: apb.Schedule_CatchUp(apb.Schedule_CatchUp_value[s.CatchUp]),
+	}
+	if !s.LastRun.IsZero() {
+		schedule.LastRun = s.LastRun.Unix()
+	}
+	if next := s.trigger.next(time.Now()); !next.IsZero() {
+		schedule.NextRun = next.Unix()
+	}
+	return schedule
+}
+
+// due returns the actions of the schedules which fired between their last
+// check and now, keyed by schedule name, following their catch-up policies
+// for missed runs. The schedules are only saved when one fired or had a
+// missed run skipped.
+func (sc *schedules) due(now time.Time) map[string]*action {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	actions := map[string]*action{}
+	changed := false
+	for name, s := range sc.schedules {
+		var latest time.Time
+		for t := s.trigger.next(s.Checked.In(now.Location())); !t.IsZero() && !t.After(now); t = s.trigger.next(t) {
+			latest = t
+		}
+		s.Checked = now
+		if latest.IsZero() {
+			continue
+		}
+		changed = true
+		if now.Sub(latest) >= time.Minute && s.CatchUp != apb.Schedule_RUN_ONCE.String() {
+			log.Printf("skipping missed run of schedule %s at %v", name, latest)
+			continue
+		}
+		s.LastRun = latest
+		actions[name] = s.Action
+	}
+	if !changed {
+		return actions
+	}
+	if err := sc.store.save("schedules", sc.schedules); err != nil {
+		log.Printf("unable to save schedules: %v", err)
+	}
+	return actions
+}
+
+// CreateSchedule adds a schedule running an action, for example
+// "0 7 * * mon-fri" to turn on the coffee maker on weekdays at 7:00, or
+// "@sunset -30m" to turn on the porch lamp half an hour before sunset.
+// Schedules use the configured timezone.
+func (s *Server) CreateSchedule(ctx context.Context, in *apb.CreateScheduleRequest) (*apb.Schedule, error) {
+	if in.Schedule == nil || in.Schedule.Name == "" {
+		return nil, fmt.Errorf("a schedule name is required")
+	}
+	name := rename(in.Schedule.Name)
+	t, err := parseTrigger(in.Schedule.Spec, s.location())
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.newAction(in.Schedule.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	s.schedules.mutex.Lock()
+	defer s.schedules.mutex.Unlock()
+	if _, ok := s.schedules.schedules[name]; ok {
+		return nil, fmt.Errorf("schedule %s already exists", name)
+	}
+	sched := &schedule{
+		Spec:    in.Schedule.Spec,
+		Action:  a,
+		CatchUp: in.Schedule.CatchUp.String(),
+		Checked: time.Now(),
+		trigger: t,
+	}
+	s.schedules.schedules[name] = sched
+	if err := s.schedules.store.save("schedules", s.schedules.schedules); err != nil {
+		delete(s.schedules.schedules, name)
+		return nil, err
+	}
+	return s.schedules.api(name, sched), nil
+}
+
+// ListSchedules lists all the schedules.
+func (s *Server) ListSchedules(ctx context.Context, _ *apb.ListSchedulesRequest) (*apb.ListSchedulesResponse, error) {
+	s.schedules.mutex.Lock()
+	defer s.schedules.mutex.Unlock()
+	list := []*apb.Schedule{}
+	for name, sched := range s.schedules.schedules {
+		list = append(list, s.schedules.api(name, sched))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return &apb.ListSchedulesResponse{Schedule: list}, nil
+}
+
+// DeleteSchedule deletes a schedule.
+func (s *Server) DeleteSchedule(ctx context.Context, in *apb.DeleteScheduleRequest) (*apb.DeleteScheduleResponse, error) {
+	name := rename(in.Name)
+	s.schedules.mutex.Lock()
+	defer s.schedules.mutex.Unlock()
+	if _, ok := s.schedules.schedules[name]; !ok {
+		return nil, fmt.Errorf("no schedule found")
+	}
+	delete(s.schedules.schedules, name)
+	if err := s.schedules.store.save("schedules", s.schedules.schedules); err != nil {
+		return nil, err
+	}
+	return &apb.DeleteScheduleResponse{}, nil
+}
+
+// scheduler runs due schedules at the start of every minute. The first
+// check happens right away, catching up on runs missed while down.
+func (s *Server) scheduler() {
+	go func() {
+		for {
+			now := time.Now().In(s.location().tz)
+			for name, a := range s.schedules.due(now) {
+				go func(name string, a *action) {
+					if err := s.run(a, source{kind: apb.HistoryRecord_SCHEDULE, detail: name}); err != nil {
+						log.Printf("schedule %s failed to %v: %v", name, a, err)
+					}
+				}(name, a)
+			}
+			time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(time.Now()))
+		}
+	}()
+}
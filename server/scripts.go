@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.starlark.net/starlark"
+	"golang.org/x/net/context"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+const (
+	// How often the scripts directory is checked for changes.
+	scriptReloadInterval = 2 * time.Second
+	// Longest a script may run when loading or handling a callback.
+	scriptTimeout = 5 * time.Second
+	// Shortest interval accepted by every().
+	minScriptInterval = time.Second
+
+	// Thread local holding the context device calls are made with.
+	contextLocal = "context"
+)
+
+// scripts are Starlark automation scripts loaded from a directory, keyed
+// by file name. Files ending in .star are loaded and reloaded as they
+// change. Scripts can only reach devices through these builtins:
+//
+//	get(name)               Returns whether a device is on.
+//	set(name, state)        Turns a device on or off.
+//	on_change(name, fn)     Calls fn(name, state) when a device changes.
+//	every(duration, fn)     Calls fn() periodically, e.g. every("5m", fn).
+//	log(*args)              Logs a message.
+//
+// on_change and every may only be called while the script loads.
+type scripts struct {
+	scripts map[string]*script
+
+	mutex *sync.Mutex
+}
+
+type script struct {
+	name    string
+	path    string
+	modTime time.Time
+	loaded  time.Time
+
+	onChange []*scriptHandler
+	stop     chan struct{}
+
+	// Callbacks of a script run one at a time.
+	run     *sync.Mutex
+	mutex   *sync.Mutex
+	err     error
+	errTime time.Time
+}
+
+type scriptHandler struct {
+	udn string
+	fn  starlark.Callable
+}
+
+func (sc *script) setErr(err error) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.err = err
+	sc.errTime = time.Now()
+}
+
+// call runs a script function within the time limit.
+func (sc *script) call(fn starlark.Callable, args ...starlark.Value) {
+	sc.run.Lock()
+	defer sc.run.Unlock()
+	thread, done := sc.thread()
+	defer done()
+	if _, err := starlark.Call(thread, fn, args, nil); err != nil {
+		log.Printf("script %s: %v", sc.name, err)
+		sc.setErr(err)
+	}
+}
+
+// thread returns a thread to run script code on, cancelled once the time
+// limit is up. Device calls made by the builtins stop retrying and locating
+// moved devices by then too, only a request already sent to a device may
+// run on until it times out.
+// The returned func must be called once the code returns.
+func (sc *script) thread() (*starlark.Thread, func()) {
+	ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout)
+	thread := &starlark.Thread{
+		Name: sc.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Printf("script %s: %s", sc.name, msg)
+		},
+	}
+	thread.SetLocal(contextLocal, ctx)
+	timer := time.AfterFunc(scriptTimeout, func() { thread.Cancel("time limit exceeded") })
+	return thread, func() {
+		timer.Stop()
+		cancel()
+	}
+}
+
+// threadContext returns the context for device calls made by script code,
+// with an error if the time limit is already up.
+func threadContext(thread *starlark.Thread) (context.Context, error) {
+	ctx := thread.Local(contextLocal).(context.Context)
+	return ctx, ctx.Err()
+}
+
+// loadScript runs a script file, registering the callbacks it sets up.
+// Callbacks from every() start running right away.
+func (s *Server) loadScript(path, name string, modTime time.Time) *script {
+	sc := &script{
+		name:    name,
+		path:    path,
+		modTime: modTime,
+		loaded:  time.Now(),
+		stop:    make(chan struct{}),
+		run:     &sync.Mutex{},
+		mutex:   &sync.Mutex{},
+	}
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		sc.setErr(err)
+		return sc
+	}
+
+	type ticker struct {
+		interval time.Duration
+		fn       starlark.Callable
+	}
+	tickers := []ticker{}
+	// Callbacks can only be registered while the script is loading.
+	loading := true
+	predeclared := starlark.StringDict{
+		"get": starlark.NewBuiltin("get", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var name string
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &name); err != nil {
+				return nil, err
+			}
+			ctx, err := threadContext(thread)
+			if err != nil {
+				return nil, err
+			}
+			device, err := s.GetDevice(ctx, &apb.GetDeviceRequest{Name: name})
+			if err != nil {
+				return nil, err
+			}
+			return starlark.Bool(device.State), nil
+		}),
+		"set": starlark.NewBuiltin("set", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var name string
+			var state bool
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &name, &state); err != nil {
+				return nil, err
+			}
+			ctx, err := threadContext(thread)
+			if err != nil {
+				return nil, err
+			}
+			d, err := s.lookupDevice(name)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := s.applyDevice(ctx, d, &apb.Device{State: state}, source{kind: apb.HistoryRecord_SCRIPT, detail: sc.name}); err != nil {
+				return nil, err
+			}
+			return starlark.None, nil
+		}),
+		"on_change": starlark.NewBuiltin("on_change", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var name string
+			var fn starlark.Callable
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &name, &fn); err != nil {
+				return nil, err
+			}
+			if !loading {
+				return nil, fmt.Errorf("%s can only be called at the top level of a script", b.Name())
+			}
+			d, err := s.lookupDevice(name)
+			if err != nil {
+				return nil, err
+			}
+			sc.onChange = append(sc.onChange, &scriptHandler{udn: d.UDN, fn: fn})
+			return starlark.None, nil
+		}),
+		"every": starlark.NewBuiltin("every", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var interval string
+			var fn starlark.Callable
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &interval, &fn); err != nil {
+				return nil, err
+			}
+			if !loading {
+				return nil, fmt.Errorf("%s can only be called at the top level of a script", b.Name())
+			}
+			d, err := time.ParseDuration(interval)
+			if err != nil {
+				return nil, err
+			}
+			if d < minScriptInterval {
+				return nil, fmt.Errorf("%s: interval must be at least %v", b.Name(), minScriptInterval)
+			}
+			tickers = append(tickers, ticker{interval: d, fn: fn})
+			return starlark.None, nil
+		}),
+		"log": starlark.NewBuiltin("log", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			parts := []string{}
+			for _, a := range args {
+				if str, ok := a.(starlark.String); ok {
+					parts = append(parts, string(str))
+				} else {
+					parts = append(parts, a.String())
+				}
+			}
+			log.Printf("script %s: %s", sc.name, strings.Join(parts, " "))
+			return starlark.None, nil
+		}),
+	}
+
+	thread, done := sc.thread()
+	_, err = starlark.ExecFile(thread, path, src, predeclared)
+	done()
+	loading = false
+	if err != nil {
+		sc.onChange = nil
+		sc.setErr(err)
+		return sc
+	}
+
+	for _, t := range tickers {
+		go func(t ticker) {
+			tick := time.NewTicker(t.interval)
+			defer tick.Stop()
+			for {
+				select {
+				case <-sc.stop:
+					return
+				case <-tick.C:
+					sc.call(t.fn)
+				}
+			}
+		}(t)
+	}
+	return sc
+}
+
+// reloadScripts loads new and changed scripts and unloads removed ones.
+// A change of scripts directory replaces all the scripts.
+func (s *Server) reloadScripts() {
+	dir := s.config().ScriptsDir
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		files = nil // A missing directory simply has no scripts.
+	}
+	found := map[string]bool{}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".star" {
+			continue
+		}
+		name := f.Name()
+		path := filepath.Join(dir, name)
+		found[name] = true
+
+		s.scripts.mutex.Lock()
+		old, ok := s.scripts.scripts[name]
+		s.scripts.mutex.Unlock()
+		if ok && old.path == path && old.modTime.Equal(f.ModTime()) {
+			continue
+		}
+		if ok {
+			close(old.stop)
+		}
+		log.Printf("loading script %s", name)
+		sc := s.loadScript(path, name, f.ModTime())
+		s.scripts.mutex.Lock()
+		s.scripts.scripts[name] = sc
+		s.scripts.mutex.Unlock()
+	}
+
+	s.scripts.mutex.Lock()
+	defer s.scripts.mutex.Unlock()
+	for name, sc := range s.scripts.scripts {
+		if !found[name] {
+			log.Printf("unloading script %s", name)
+			close(sc.stop)
+			delete(s.scripts.scripts, name)
+		}
+	}
+}
+
+// scriptEngine hot-reloads the scripts and delivers device state changes to
+// their on_change callbacks.
+func (s *Server) scriptEngine() {
+	s.reloadScripts()
+	go func() {
+		for range time.Tick(scriptReloadInterval) {
+			s.reloadScripts()
+		}
+	}()
+
+	events, _ := s.watch()
+	go func() {
+		for e := range events {
+			if e.Type != apb.DeviceEvent_STATE_CHANGED {
+				continue
+			}
+			s.scripts.mutex.Lock()
+			for _, sc := range s.scripts.scripts {
+				for _, h := range sc.onChange {
+					if h.udn == e.Device.Udn {
+						go sc.call(h.fn, starlark.String(e.Device.Name), starlark.Bool(e.Device.State))
+					}
+				}
+			}
+			s.scripts.mutex.Unlock()
+		}
+	}()
+}
+
+// ListScripts lists the loaded scripts and the last error each one hit,
+// while loading or in a callback.
+func (s *Server) ListScripts(ctx context.Context, _ *apb.ListScriptsRequest) (*apb.ListScriptsResponse, error) {
+	s.scripts.mutex.Lock()
+	defer s.scripts.mutex.Unlock()
+	list := []*apb.Script{}
+	for _, sc := range s.scripts.scripts {
+		script := &apb.Script{
+			Name:   sc.name,
+			Loaded: sc.loaded.Unix(),
+		}
+		sc.mutex.Lock()
+		if sc.err != nil {
+			script.Error = sc.err.Error()
+			script.ErrorTime = sc.errTime.Unix()
+		}
+		sc.mutex.Unlock()
+		list = append(list, script)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return &apb.ListScriptsResponse{Script: list}, nil
+}
@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"log"
+	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bamnet/apartment/wemo"
@@ -13,37 +16,168 @@ import (
 	apb "github.com/bamnet/apartment/proto/apartment"
 )
 
-// Number of scans in-a-row a device must be not found to be removed.
-const missingThreshold = 5
-
 // Server holds the internal device connections.
+// Devices are keyed by their UDN, which unlike their names is unique.
 type Server struct {
 	devices map[string]*wemo.Device
 	missing map[string]int
+	states  map[string]wemo.PowerState // Current state, kept fresh by device events.
+	store   *store
+
+	// States which may have changed since they were seen, stored before the
+	// server started or kept while a device is resubscribed to. They are only
+	// compared against to tell whether a device changed.
+	lastKnown map[string]wemo.PowerState
+
+	// Changes the server made which devices have yet to report, keyed by UDN.
+	expected map[string]*expectation
+
+	// Devices found by the latest scan of the probe networks, keyed by UDN.
+	// They count as found on every discovery pass until the next scan.
+	probed map[string]*wemo.Device
 
-	mutex *sync.Mutex
+	// Evented devices the server gave up subscribing to, keyed by UDN.
+	// Subscribing is tried again on the next discovery pass.
+	unsubscribed map[string]bool
+
+	names      *names
+	groups     *groups
+	scenes     *scenes
+	schedules  *schedules
+	rules      *rules
+	scripts    *scripts
+	timers     *timers
+	registry   *registry
+	loc        *atomic.Value // Holds the current *location.
+	cfg        *atomic.Value // Holds the current *config.
+	subscriber *wemo.Subscriber
+	watchers   map[chan *apb.DeviceEvent]bool
+	mutex      *sync.Mutex
+	discovery  *sync.Mutex // Serializes discovery passes.
 }
 
 // NewServer builds a new Apartment server.
-// It connects and maps the initial set of devices.
-func NewServer() (*Server, error) {
-	aSrv := &Server{
-		devices: map[string]*wemo.Device{},
-		missing: map[string]int{},
-		mutex:   &sync.Mutex{},
+// It connects and maps the initial set of devices, subscribing to their
+// state change events. Server-side settings such as aliases, groups and
+// schedules, as well as the known devices, are kept in a store in the data
+// directory. Stored devices are served right away.
+func NewServer(cfg *config) (*Server, error) {
+	loc, err := parseLocation(cfg.Location, cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %v", err)
+	}
+	st, err := openStore(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	devices, states, err := st.devices()
+	if err != nil {
+		return nil, err
+	}
+	n, err := loadNames(st)
+	if err != nil {
+		return nil, err
+	}
+	g, err := loadGroups(st)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := loadScenes(st)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := loadSchedules(st, loc)
+	if err != nil {
+		return nil, err
+	}
+	r, err := loadRules(st, loc)
+	if err != nil {
+		return nil, err
+	}
+	t, err := loadTimers(st)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := loadRegistry(st)
+	if err != nil {
+		return nil, err
 	}
-	if err := aSrv.mapDevices(); err != nil {
+	subscriber, err := wemo.NewSubscriber(cfg.EventListen)
+	if err != nil {
 		return nil, err
 	}
-	aSrv.remapper(60 * time.Second)
+	aSrv := &Server{
+		devices:      devices,
+		missing:      map[string]int{},
+		states:       map[string]wemo.PowerState{},
+		store:        st,
+		lastKnown:    states,
+		expected:     map[string]*expectation{},
+		probed:       map[string]*wemo.Device{},
+		unsubscribed: map[string]bool{},
+		names:        n,
+		groups:       g,
+		scenes:       sc,
+		schedules:    sch,
+		rules:        r,
+		scripts: &scripts{
+			scripts: map[string]*script{},
+			mutex:   &sync.Mutex{},
+		},
+		timers:     t,
+		registry:   reg,
+		loc:        &atomic.Value{},
+		cfg:        &atomic.Value{},
+		subscriber: subscriber,
+		watchers:   map[chan *apb.DeviceEvent]bool{},
+		mutex:      &sync.Mutex{},
+		discovery:  &sync.Mutex{},
+	}
+	aSrv.loc.Store(loc)
+	aSrv.cfg.Store(cfg)
+	go aSrv.watchEvents()
+	if len(devices) == 0 {
+		if err := aSrv.mapDevices(); err != nil {
+			return nil, err
+		}
+	} else {
+		// Serve the stored devices right away and reconcile them with the
+		// network in the background.
+		for _, d := range devices {
+			go aSrv.subscribe(d)
+		}
+		go func() {
+			if err := aSrv.mapDevices(); err != nil {
+				log.Printf("unable to discover devices: %v", err)
+			}
+		}()
+	}
+	aSrv.remapper()
+	aSrv.prober()
+	aSrv.startTimers()
+	aSrv.scheduler()
+	aSrv.ruleEngine()
+	aSrv.scriptEngine()
 
 	return aSrv, nil
 }
 
 func (s *Server) mapDevices() error {
-	devices, err := wemo.DiscoverDevices()
-	if err != nil {
-		return err
+	s.discovery.Lock()
+	defer s.discovery.Unlock()
+
+	cfg := s.config()
+	hosts := append([]string{}, cfg.Discovery.StaticHosts...)
+	devices := wemo.DevicesAt(append(hosts, s.registry.hosts()...)...)
+	if !cfg.Discovery.DisableSSDP {
+		found, err := wemo.DiscoverTypes(cfg.Discovery.Types...)
+		if err != nil {
+			if len(devices) == 0 {
+				return err
+			}
+			log.Printf("SSDP discovery failed: %v", err)
+		}
+		devices = append(devices, found...)
 	}
 
 	s.mutex.Lock()
@@ -51,116 +185,669 @@ func (s *Server) mapDevices() error {
 
 	found := map[string]bool{} // Keys of newly found devices.
 	for _, d := range devices {
-		key := rename(d.FriendlyName)
-		s.devices[key] = d
-		found[key] = true
-		delete(s.missing, key) // Remove the device from the missing map.
+		if s.registry.forgotten(d.UDN) {
+			continue
+		}
+		found[d.UDN] = true
+		s.register(d)
+	}
+	// Probe results may be older, only use them for devices not found now.
+	for key, d := range s.probed {
+		if !found[key] && !s.registry.forgotten(key) {
+			found[key] = true
+			s.register(d)
+		}
 	}
 
 	// Loop through all the existing devices, see if we found them during the
-	// latest scan. Increase the missing count of those not found.
+	// latest scan. Increase the missing count of those not found. Devices
+	// added by hand are kept until they are forgotten.
 	for key := range s.devices {
-		if _, exists := found[key]; !exists {
+		if _, exists := found[key]; !exists && !s.registry.pinned(key) {
 			s.missing[key]++
 		}
 	}
 
 	// Loop through the missing devices and remove them if missing for too long.
 	for key, count := range s.missing {
-		if count > missingThreshold {
-			delete(s.devices, key)
-			delete(s.missing, key)
+		if count > cfg.Discovery.MissingThreshold {
+			s.remove(key)
 		}
 	}
 	return nil
 }
 
-func (s *Server) remapper(interval time.Duration) {
-	ticker := time.NewTicker(interval)
+// register adds a device to the device map, or updates its host.
+// The caller must hold the mutex.
+func (s *Server) register(d *wemo.Device) {
+	key := d.UDN
+	delete(s.missing, key) // Remove the device from the missing map.
+
+	// Keep the existing connection (and its subscription) at the same host,
+	// picking up changes such as a rename in the WeMo app or new firmware.
+	if old, ok := s.devices[key]; ok {
+		if old.Host == d.Host {
+			if !sameMetadata(old, d) {
+				s.swapDevice(old, d)
+				if old.FriendlyName != d.FriendlyName {
+					s.publish(apb.DeviceEvent_RENAMED, s.apiDevice(d, s.states[key]))
+				}
+			}
+			if s.unsubscribed[key] {
+				delete(s.unsubscribed, key)
+				go s.subscribe(s.devices[key])
+			}
+			return
+		}
+		s.unsubscribe(key, old)
+	} else {
+		s.publish(apb.DeviceEvent_DISCOVERED, s.apiDevice(d, wemo.Unknown))
+	}
+	s.devices[key] = d
+	s.store.putDevice(d)
+	s.registry.moved(d)
+	go s.subscribe(d)
+}
+
+// sameMetadata reports whether two values of a device at the same host
+// describe it the same way.
+func sameMetadata(a, b *wemo.Device) bool {
+	return a.FriendlyName == b.FriendlyName &&
+		a.DeviceType == b.DeviceType &&
+		a.SerialNumber == b.SerialNumber &&
+		a.MACAddress == b.MACAddress &&
+		a.ModelName == b.ModelName &&
+		a.ModelNumber == b.ModelNumber &&
+		a.FirmwareVersion == b.FirmwareVersion &&
+		a.IconURL == b.IconURL
+}
+
+// swapDevice replaces a device with another value for it at the same host,
+// such as a renamed copy. Its subscription and cached state carry over.
+// The caller must hold the mutex.
+func (s *Server) swapDevice(old, d *wemo.Device) {
+	if old.Evented() && !s.unsubscribed[d.UDN] {
+		if err := s.subscriber.Replace(old, d); err != nil {
+			state, ok := s.states[d.UDN]
+			s.unsubscribe(d.UDN, old)
+			if ok {
+				s.states[d.UDN] = state
+				delete(s.lastKnown, d.UDN)
+			}
+			go s.subscribe(d)
+		}
+	}
+	s.devices[d.UDN] = d
+	s.store.putDevice(d)
+}
+
+// remove drops a device from the device map and the store.
+// The caller must hold the mutex.
+func (s *Server) remove(key string) {
+	d := s.devices[key]
+	s.unsubscribe(key, d)
+	delete(s.devices, key)
+	delete(s.missing, key)
+	delete(s.states, key)
+	delete(s.lastKnown, key)
+	s.store.deleteDevice(key)
+	s.publish(apb.DeviceEvent_REMOVED, s.apiDevice(d, wemo.Unknown))
+}
+
+func (s *Server) remapper() {
 	go func() {
 		for {
-			select {
-			case <-ticker.C:
-				s.mapDevices()
+			time.Sleep(s.config().Discovery.Interval)
+			s.mapDevices()
+		}
+	}()
+}
+
+// prober scans the probe networks for devices, on its own interval as
+// scans are slow. Newly found devices are added right away, the discovery
+// passes in between keep them from going missing.
+func (s *Server) prober() {
+	go func() {
+		for {
+			cfg := s.config()
+			probed := map[string]*wemo.Device{}
+			for _, cidr := range cfg.Discovery.Probe {
+				found, err := wemo.ProbeNetwork(cidr, cfg.Discovery.Types...)
+				if err != nil {
+					log.Printf("unable to probe %s: %v", cidr, err)
+					continue
+				}
+				for _, d := range found {
+					probed[d.UDN] = d
+				}
 			}
+
+			s.mutex.Lock()
+			s.probed = probed
+			for _, d := range probed {
+				if !s.registry.forgotten(d.UDN) {
+					s.register(d)
+				}
+			}
+			s.mutex.Unlock()
+
+			time.Sleep(cfg.Discovery.ProbeInterval)
 		}
 	}()
 }
 
+// subscribe asks a device to push its state changes to the server.
+// If the device cannot be subscribed to, it is tried again on the next
+// discovery pass. A device replaced meanwhile is unsubscribed from again.
+func (s *Server) subscribe(d *wemo.Device) {
+	if !d.Evented() {
+		return
+	}
+	err := backoff.Retry(func() error {
+		return s.subscriber.Subscribe(d)
+	}, s.config().backOff())
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	current := s.devices[d.UDN] == d
+	switch {
+	case err != nil:
+		log.Printf("unable to subscribe to %s: %v", d.FriendlyName, err)
+		if current {
+			s.unsubscribed[d.UDN] = true
+		}
+	case !current:
+		// The device was replaced while subscribing, and its replacement
+		// subscribed on its own.
+		go s.subscriber.Unsubscribe(d)
+	}
+}
+
+// unsubscribe stops events from a device. Its cached state is no longer
+// current but is kept as the last known one.
+// The caller must hold the mutex.
+func (s *Server) unsubscribe(key string, d *wemo.Device) {
+	if state, ok := s.states[key]; ok {
+		s.lastKnown[key] = state
+	}
+	delete(s.states, key)
+	delete(s.unsubscribed, key)
+	if !d.Evented() {
+		return
+	}
+	go func() {
+		if err := s.subscriber.Unsubscribe(d); err != nil {
+			log.Printf("unable to unsubscribe from %s: %v", d.FriendlyName, err)
+		}
+	}()
+}
+
+// watchEvents updates the state cache as devices report changes.
+func (s *Server) watchEvents() {
+	for e := range s.subscriber.Events() {
+		s.cacheState(e.Device, e.State, eventSource)
+	}
+}
+
 // ListDevices lists all the devices the server is aware of.
-// It does not attempt to identify the state of the devices.
+// It does not attempt to identify the state of the devices, but includes
+// the last state they reported when known.
 func (s *Server) ListDevices(ctx context.Context, _ *apb.ListDevicesRequest) (*apb.ListDevicesResponse, error) {
 	resp := apb.ListDevicesResponse{}
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	for n, d := range s.devices {
-		device := &apb.Device{
-			Name:         n,
-			FriendlyName: d.FriendlyName,
-		}
-		resp.Device = append(resp.Device, device)
+	for key, d := range s.devices {
+		resp.Device = append(resp.Device, s.apiDevice(d, s.states[key]))
 	}
 	return &resp, nil
 }
 
 // GetDevice gets the latest information about a Device.
+// The state comes from the event cache while the server is subscribed to
+// the device's changes, otherwise the device is asked directly.
 func (s *Server) GetDevice(ctx context.Context, in *apb.GetDeviceRequest) (*apb.Device, error) {
 	d, err := s.lookupDevice(in.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	return apiDevice(d)
+	s.mutex.Lock()
+	state, ok := s.states[d.UDN]
+	s.mutex.Unlock()
+
+	if !ok || !s.subscriber.Subscribed(d) {
+		if d, err = s.do(ctx, d, func(d *wemo.Device) (err error) {
+			state, err = d.PowerState()
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		s.cacheState(d, state, discoverySource)
+	}
+
+	device := s.apiDevice(d, state)
+	if err := s.addDetails(ctx, device, d); err != nil {
+		return nil, err
+	}
+	return device, nil
 }
 
 // UpdateDevice sets the state of a Device.
+// Dimmable devices being turned on are set to the requested brightness, and
+// tunable bulbs to the requested color temperature, if one is given. Makers
+// are switched between toggle and momentary mode if asked to.
 func (s *Server) UpdateDevice(ctx context.Context, in *apb.UpdateDeviceRequest) (*apb.Device, error) {
 	d, err := s.lookupDevice(in.Device.Name)
 	if err != nil {
 		return nil, err
 	}
+	if in.UpdateMomentary {
+		if !d.IsMaker() {
+			return nil, fmt.Errorf("%s is not a maker and has no momentary mode", rename(d.FriendlyName))
+		}
+		if d, err = s.do(ctx, d, func(d *wemo.Device) error {
+			return d.SetMomentary(in.Device.Momentary)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return s.applyDevice(ctx, d, in.Device, apiSource(ctx))
+}
 
-	if err := backoff.Retry(func() error {
-		return d.SetState(in.Device.State)
-	}, backoff.NewExponentialBackOff()); err != nil {
+// applyDevice sets a device to the state, brightness and color temperature
+// of want, then reads back its new state and details. Device calls are
+// retried until the deadline of ctx, if it has one.
+func (s *Server) applyDevice(ctx context.Context, d *wemo.Device, want *apb.Device, src source) (*apb.Device, error) {
+	d, state, err := s.applyState(ctx, d, want, src)
+	if err != nil {
+		return nil, err
+	}
+	device := s.apiDevice(d, state)
+	if err := s.addDetails(ctx, device, d); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// applyState is applyDevice without reading the details. The device last
+// used is returned, with its new state.
+func (s *Server) applyState(ctx context.Context, d *wemo.Device, want *apb.Device, src source) (*wemo.Device, wemo.PowerState, error) {
+	if d.ReadOnly() {
+		return d, wemo.Unknown, fmt.Errorf("%s is a sensor and cannot be updated", rename(d.FriendlyName))
+	}
+
+	var err error
+	dim := d.Dimmable() && want.State && want.Brightness > 0
+	s.expect(d.UDN, want.State, src)
+	if d, err = s.do(ctx, d, func(d *wemo.Device) error {
+		if dim {
+			return d.SetBrightness(int(want.Brightness))
+		}
+		return d.SetState(want.State)
+	}); err != nil {
+		return d, wemo.Unknown, err
+	}
+	if d.Tunable() && want.State && want.ColorTemperature > 0 {
+		if d, err = s.do(ctx, d, func(d *wemo.Device) error {
+			return d.SetColorTemperature(int(want.ColorTemperature))
+		}); err != nil {
+			return d, wemo.Unknown, err
+		}
+	}
+
+	var state wemo.PowerState
+	if d, err = s.do(ctx, d, func(d *wemo.Device) (err error) {
+		state, err = d.PowerState()
+		return err
+	}); err != nil {
+		return d, wemo.Unknown, err
+	}
+	s.cacheState(d, state, src)
+	return d, state, nil
+}
+
+// RenameDevice changes the friendly name of a Device, on the device itself.
+// Its name in the API changes accordingly.
+func (s *Server) RenameDevice(ctx context.Context, in *apb.RenameDeviceRequest) (*apb.Device, error) {
+	if in.FriendlyName == "" {
+		return nil, fmt.Errorf("a friendly name is required")
+	}
+	d, err := s.lookupDevice(in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if d, err = s.do(ctx, d, func(d *wemo.Device) error {
+		return d.SetFriendlyName(in.FriendlyName)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Swap in the renamed device in one go, so lookups never see a mix of
+	// old and new names. The device may have been replaced meanwhile, for
+	// example if it moved, so the current one is renamed. Its subscription
+	// and cached state carry over.
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	cur, ok := s.devices[d.UDN]
+	if !ok {
+		return nil, fmt.Errorf("%s was removed while being renamed", in.FriendlyName)
+	}
+	renamed := *cur
+	renamed.FriendlyName = in.FriendlyName
+	s.swapDevice(cur, &renamed)
+
+	device := s.apiDevice(&renamed, s.states[d.UDN])
+	s.publish(apb.DeviceEvent_RENAMED, device)
+	return device, nil
+}
+
+// SetDeviceNames sets the server-side display name and aliases of a Device.
+// Unlike RenameDevice, nothing is changed on the device itself.
+func (s *Server) SetDeviceNames(ctx context.Context, in *apb.SetDeviceNamesRequest) (*apb.Device, error) {
+	d, err := s.lookupDevice(in.Name)
+	if err != nil {
 		return nil, err
 	}
 
-	return apiDevice(d)
+	aliases := []string{}
+	for _, a := range in.Aliases {
+		if a = rename(a); a != "" {
+			aliases = append(aliases, a)
+		}
+	}
+	for _, a := range aliases {
+		if _, ok := s.config().alias(a); ok {
+			return nil, fmt.Errorf("alias %s is defined in the config file", a)
+		}
+	}
+	if err := s.names.set(d.UDN, in.DisplayName, aliases); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	device := s.apiDevice(d, s.states[d.UDN])
+	s.publish(apb.DeviceEvent_RENAMED, device)
+	return device, nil
+}
+
+// cacheState records the state of a device, unless it has since been
+// replaced in the device map. Watchers are told about changes from the last
+// known state, which are also added to the history along with what caused
+// them.
+func (s *Server) cacheState(d *wemo.Device, state wemo.PowerState, src source) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.devices[d.UDN] != d {
+		return
+	}
+	old, ok := s.states[d.UDN]
+	if ok && old == state {
+		return
+	}
+	if !ok {
+		old, ok = s.lastKnown[d.UDN]
+		delete(s.lastKnown, d.UDN)
+	}
+	s.states[d.UDN] = state
+	if ok && old == state {
+		return // Only confirms the last known state.
+	}
+	s.store.putState(d.UDN, state)
+	src = s.attribute(d.UDN, state, src)
+	s.store.record(&historyRecord{
+		Time:     time.Now(),
+		UDN:      d.UDN,
+		Name:     rename(d.FriendlyName),
+		OldState: apiState(old).String(),
+		NewState: apiState(state).String(),
+		Source:   src.kind.String(),
+		Detail:   src.detail,
+	})
+	s.publish(apb.DeviceEvent_STATE_CHANGED, s.apiDevice(d, state))
 }
 
 // lookupDevice is a shortcut function to try and find a device in
-// the internal device map.
+// the internal device map, by UDN, alias or name.
 func (s *Server) lookupDevice(name string) (*wemo.Device, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	d, ok := s.devices[name]
-	if !ok {
+	if d, ok := s.devices[name]; ok {
+		return d, nil
+	}
+	if udn, ok := s.names.lookup(name); ok {
+		if d, ok := s.devices[udn]; ok {
+			return d, nil
+		}
+	}
+	if device, ok := s.config().alias(name); ok {
+		if d, ok := s.devices[device]; ok {
+			return d, nil
+		}
+		name = rename(device)
+	}
+
+	var found *wemo.Device
+	for _, d := range s.devices {
+		if rename(d.FriendlyName) != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("multiple devices named %s, use the udn", name)
+		}
+		found = d
+	}
+	if found == nil {
 		return nil, fmt.Errorf("no device found")
 	}
-	return d, nil
+	return found, nil
+}
+
+// do runs op against a device, retrying on errors. If the device cannot be
+// reached it may have a new address, so it is located again and op retried
+// there. Retries stop at the deadline of ctx. The device op last ran against
+// is returned.
+func (s *Server) do(ctx context.Context, d *wemo.Device, op func(*wemo.Device) error) (*wemo.Device, error) {
+	var connErr error
+	retry := func(d *wemo.Device) error {
+		return backoff.Retry(func() error {
+			connErr = op(d)
+			if unreachable(connErr) {
+				return nil // Stop retrying, the device needs to be located.
+			}
+			return connErr
+		}, s.backOff(ctx))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return d, err
+	}
+	if err := retry(d); err != nil || connErr == nil {
+		return d, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return d, connErr
+	}
+	moved, err := d.LocateContext(ctx)
+	if err != nil {
+		log.Printf("unable to locate %s: %v", d.FriendlyName, err)
+		return d, connErr
+	}
+	s.replaceDevice(d, moved)
+	if err := retry(moved); err != nil {
+		return moved, err
+	}
+	return moved, connErr
+}
+
+// replaceDevice swaps a device for a new connection to it, for example after
+// its address changed.
+func (s *Server) replaceDevice(old, d *wemo.Device) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.devices[old.UDN] != old {
+		return
+	}
+	if old.Host != d.Host {
+		log.Printf("%s moved from %s to %s", d.FriendlyName, old.Host, d.Host)
+	}
+	s.unsubscribe(old.UDN, old)
+	s.devices[d.UDN] = d
+	s.store.putDevice(d)
+	s.registry.moved(d)
+	go s.subscribe(d)
+}
+
+// unreachable reports whether err means a device could not be contacted,
+// rather than it rejecting a request.
+func unreachable(err error) bool {
+	_, ok := err.(*url.Error)
+	return ok
 }
 
 func rename(in string) string {
 	return strings.ToLower(in)
 }
 
-// apiDevice converts a wemo.Device to an apartment protobuf Device.
-func apiDevice(d *wemo.Device) (*apb.Device, error) {
+// apiDevice converts a wemo.Device and its state to an apartment protobuf Device.
+func (s *Server) apiDevice(d *wemo.Device, state wemo.PowerState) *apb.Device {
 	device := &apb.Device{
 		Name:         rename(d.FriendlyName),
 		FriendlyName: d.FriendlyName,
+		State:        state.IsOn(),
+		PowerState:   apiState(state),
+		Dimmable:     d.Dimmable(),
+		Type:         apiType(d.DeviceType),
+		ReadOnly:     d.ReadOnly(),
+		Tunable:      d.Tunable(),
+
+		Udn:             d.UDN,
+		SerialNumber:    d.SerialNumber,
+		MacAddress:      d.MACAddress,
+		ModelName:       d.ModelName,
+		ModelNumber:     d.ModelNumber,
+		FirmwareVersion: d.FirmwareVersion,
+		DeviceType:      d.DeviceType,
+		IconUrl:         d.IconURL,
+	}
+	device.DisplayName, device.Aliases = s.names.get(d.UDN)
+	device.Aliases = append(device.Aliases, s.config().aliasesOf(d)...)
+	device.Timer = s.timers.get(d.UDN)
+	if d.DeviceType == wemo.MotionType {
+		device.Sensor = &apb.Sensor{
+			Present:   true,
+			Triggered: state.IsOn(),
+		}
 	}
+	return device
+}
 
-	if err := backoff.Retry(func() error {
-		state, err := d.State()
-		if err != nil {
+// apiType converts a UPnP device type to its protobuf equivalent.
+func apiType(t string) apb.Device_Type {
+	switch t {
+	case wemo.ControlleeType:
+		return apb.Device_SWITCH
+	case wemo.InsightType:
+		return apb.Device_INSIGHT
+	case wemo.DimmerType:
+		return apb.Device_DIMMER
+	case wemo.LightSwitchType:
+		return apb.Device_LIGHT_SWITCH
+	case wemo.MakerType:
+		return apb.Device_MAKER
+	case wemo.MotionType:
+		return apb.Device_MOTION
+	case wemo.BulbType:
+		return apb.Device_BULB
+	}
+	return apb.Device_OTHER
+}
+
+// apiState converts a wemo.PowerState to its protobuf equivalent.
+func apiState(state wemo.PowerState) apb.PowerState {
+	switch state {
+	case wemo.Off:
+		return apb.PowerState_OFF
+	case wemo.On:
+		return apb.PowerState_ON
+	case wemo.Standby:
+		return apb.PowerState_STANDBY
+	}
+	return apb.PowerState_UNKNOWN
+}
+
+// addDetails asks a device for the readings specific to its type, such as
+// energy use or brightness, and adds them to the protobuf Device.
+func (s *Server) addDetails(ctx context.Context, device *apb.Device, d *wemo.Device) error {
+	var err error
+	if d.DeviceType == wemo.InsightType {
+		if device.Power, err = s.apiPower(ctx, d); err != nil {
+			return err
+		}
+	}
+	if d.Dimmable() {
+		if device.Brightness, err = s.apiBrightness(ctx, d); err != nil {
+			return err
+		}
+	}
+	if d.Tunable() {
+		var k int
+		if err := backoff.Retry(func() error {
+			var err error
+			k, err = d.ColorTemperature()
+			return err
+		}, s.backOff(ctx)); err != nil {
+			return err
+		}
+		device.ColorTemperature = int32(k)
+	}
+	if d.IsMaker() {
+		var attrs *wemo.MakerAttributes
+		if err := backoff.Retry(func() error {
+			var err error
+			attrs, err = d.MakerAttributes()
+			return err
+		}, s.backOff(ctx)); err != nil {
 			return err
 		}
-		device.State = state
-		return nil
-	}, backoff.NewExponentialBackOff()); err != nil {
+		device.Momentary = attrs.Momentary
+		device.Sensor = &apb.Sensor{
+			Present:   attrs.SensorPresent,
+			Triggered: attrs.SensorTriggered,
+		}
+	}
+	return nil
+}
+
+// apiPower reads the energy meter of an Insight device.
+func (s *Server) apiPower(ctx context.Context, d *wemo.Device) (*apb.PowerReading, error) {
+	var p *wemo.InsightParams
+	if err := backoff.Retry(func() error {
+		var err error
+		p, err = d.InsightParams()
+		return err
+	}, s.backOff(ctx)); err != nil {
 		return nil, err
 	}
-	return device, nil
+
+	return &apb.PowerReading{
+		CurrentMw:          int64(p.CurrentPower),
+		TodayKwh:           p.TodayKWh,
+		TotalKwh:           p.TotalKWh,
+		OnTodaySeconds:     int64(p.OnToday / time.Second),
+		StandbyThresholdMw: int64(p.StandbyThreshold),
+	}, nil
+}
+
+// apiBrightness reads the brightness of a dimmable device.
+func (s *Server) apiBrightness(ctx context.Context, d *wemo.Device) (int32, error) {
+	var b int
+	err := backoff.Retry(func() error {
+		var err error
+		b, err = d.Brightness()
+		return err
+	}, s.backOff(ctx))
+	return int32(b), err
 }
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// location is where the apartment is, used to compute solar times locally.
+type location struct {
+	latitude, longitude float64
+	configured          bool
+
+	tz *time.Location
+}
+
+// parseLocation parses a "latitude,longitude" pair and an IANA timezone name.
+// An empty coords string leaves solar times unavailable, an empty tz uses
+// the server's local timezone.
+func parseLocation(coords, tz string) (*location, error) {
+	l := &location{tz: time.Local}
+	if tz != "" {
+		var err error
+		if l.tz, err = time.LoadLocation(tz); err != nil {
+			return nil, err
+		}
+	}
+	if coords == "" {
+		return l, nil
+	}
+
+	parts := strings.Split(coords, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("location %q must be latitude,longitude", coords)
+	}
+	var err error
+	if l.latitude, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err != nil || math.Abs(l.latitude) > 90 {
+		return nil, fmt.Errorf("invalid latitude %q", parts[0])
+	}
+	if l.longitude, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err != nil || math.Abs(l.longitude) > 180 {
+		return nil, fmt.Errorf("invalid longitude %q", parts[1])
+	}
+	l.configured = true
+	return l, nil
+}
+
+// Solar events.
+const (
+	sunrise = "sunrise"
+	sunset  = "sunset"
+)
+
+// solarTimes computes sunrise and sunset on the given day, in the
+// location's timezone. ok is false if the sun does not rise or set that
+// day, as happens near the poles.
+//
+// It follows the sunrise equation, which is accurate to about a minute.
+func (l *location) solarTimes(day time.Time) (rise, set time.Time, ok bool) {
+	const (
+		rad   = math.Pi / 180
+		j2000 = 2451545.0
+	)
+	y, m, d := day.In(l.tz).Date()
+	noon := time.Date(y, m, d, 12, 0, 0, 0, l.tz)
+	jd := float64(noon.Unix())/86400 + 2440587.5
+
+	// Mean solar noon, as days since J2000.
+	n := math.Round(jd - j2000 + l.longitude/360)
+	js := n - l.longitude/360
+
+	ma := math.Mod(357.5291+0.98560028*js, 360)
+	c := 1.9148*math.Sin(ma*rad) + 0.02*math.Sin(2*ma*rad) + 0.0003*math.Sin(3*ma*rad)
+	lambda := math.Mod(ma+c+180+102.9372, 360)
+	transit := j2000 + js + 0.0053*math.Sin(ma*rad) - 0.0069*math.Sin(2*lambda*rad)
+
+	sinDecl := math.Sin(lambda*rad) * math.Sin(23.4397*rad)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	cosHour := (math.Sin(-0.833*rad) - math.Sin(l.latitude*rad)*sinDecl) / (math.Cos(l.latitude*rad) * cosDecl)
+	if cosHour < -1 || cosHour > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	hour := math.Acos(cosHour) / rad / 360
+
+	julianTime := func(j float64) time.Time {
+		secs := (j - 2440587.5) * 86400
+		return time.Unix(int64(secs), 0).In(l.tz)
+	}
+	return julianTime(transit - hour), julianTime(transit + hour), true
+}
+
+// nextSolar returns the first time after t of a solar event, moved by offset,
+// on one of the allowed days of the week. The zero time is returned if there
+// is none within a year.
+func (l *location) nextSolar(event string, offset time.Duration, dow uint64, t time.Time) time.Time {
+	day := t.In(l.tz).AddDate(0, 0, -1)
+	for i := 0; i < 368; i++ {
+		rise, set, ok := l.solarTimes(day)
+		day = day.AddDate(0, 0, 1)
+		if !ok {
+			continue
+		}
+		at := rise
+		if event == sunset {
+			at = set
+		}
+		at = at.Add(offset).Truncate(time.Minute)
+		if at.After(t) && dow&(1<<uint(at.Weekday())) != 0 {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// solarSpec is a schedule relative to sunrise or sunset.
+type solarSpec struct {
+	event  string
+	offset time.Duration
+	dow    uint64
+	loc    *location
+}
+
+// parseSolar parses schedules such as "@sunset -30m" or "@sunrise mon-fri".
+func parseSolar(spec string, loc *location) (*solarSpec, error) {
+	fields := strings.Fields(spec)
+	s := &solarSpec{
+		event: strings.TrimPrefix(strings.ToLower(fields[0]), "@"),
+		dow:   0x7f,
+		loc:   loc,
+	}
+	if s.event != sunrise && s.event != sunset {
+		return nil, fmt.Errorf("unknown solar event %q", fields[0])
+	}
+	if !loc.configured {
+		return nil, fmt.Errorf("no location configured for %s schedules", s.event)
+	}
+	fields = fields[1:]
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "+") || strings.HasPrefix(fields[0], "-")) {
+		var err error
+		if s.offset, err = time.ParseDuration(fields[0]); err != nil {
+			return nil, fmt.Errorf("invalid offset %q", fields[0])
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 1 {
+		return nil, fmt.Errorf("schedule %q has too many fields", spec)
+	}
+	if len(fields) == 1 {
+		var err error
+		if s.dow, err = parseCronField(fields[0], 0, 7, dayNames); err != nil {
+			return nil, err
+		}
+		if s.dow&(1<<7) != 0 {
+			s.dow |= 1
+		}
+	}
+	return s, nil
+}
+
+func (s *solarSpec) next(t time.Time) time.Time {
+	return s.loc.nextSolar(s.event, s.offset, s.dow, t)
+}
+
+// location returns the current location.
+func (s *Server) location() *location {
+	return s.loc.Load().(*location)
+}
+
+// relocate switches to a new location and timezone. The schedules and
+// rules with triggers relative to sunrise or sunset are parsed again for
+// it, if any of them cannot be nothing changes.
+func (s *Server) relocate(coords, tz string) error {
+	loc, err := parseLocation(coords, tz)
+	if err != nil {
+		return err
+	}
+
+	s.schedules.mutex.Lock()
+	defer s.schedules.mutex.Unlock()
+	s.rules.mutex.Lock()
+	defer s.rules.mutex.Unlock()
+	schedules := map[string]trigger{}
+	for name, sch := range s.schedules.schedules {
+		if schedules[name], err = parseTrigger(sch.Spec, loc); err != nil {
+			return fmt.Errorf("schedule %s: %v", name, err)
+		}
+	}
+	rules := map[string]trigger{}
+	for name, ru := range s.rules.rules {
+		if ru.Trigger.at == nil {
+			continue
+		}
+		if rules[name], err = parseTrigger(ru.Trigger.Spec, loc); err != nil {
+			return fmt.Errorf("rule %s: %v", name, err)
+		}
+	}
+
+	for name, t := range schedules {
+		s.schedules.schedules[name].trigger = t
+	}
+	for name, t := range rules {
+		s.rules.rules[name].Trigger.at = t
+	}
+	s.loc.Store(loc)
+	return nil
+}
+
+// GetSolarTimes returns the next sunrise and sunset at the configured location.
+func (s *Server) GetSolarTimes(ctx context.Context, _ *apb.GetSolarTimesRequest) (*apb.SolarTimes, error) {
+	loc := s.location()
+	if !loc.configured {
+		return nil, fmt.Errorf("no location configured")
+	}
+	now := time.Now()
+	times := &apb.SolarTimes{
+		Latitude:  loc.latitude,
+		Longitude: loc.longitude,
+		Timezone:  loc.tz.String(),
+	}
+	if t := loc.nextSolar(sunrise, 0, 0x7f, now); !t.IsZero() {
+		times.NextSunrise = t.Unix()
+	}
+	if t := loc.nextSolar(sunset, 0, 0x7f, now); !t.IsZero() {
+		times.NextSunset = t.Unix()
+	}
+	return times, nil
+}
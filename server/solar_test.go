@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolarTimes(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		coords    string
+		tz        string
+		day       string
+		rise, set string // Empty if the sun does not rise and set.
+	}{
+		{"new york summer", "40.7128,-74.0060", "America/New_York", "2024-06-21", "05:25", "20:31"},
+		{"london winter", "51.5074,-0.1278", "Europe/London", "2024-12-21", "08:04", "15:53"},
+		{"tokyo equinox", "35.6762,139.6503", "Asia/Tokyo", "2024-03-20", "05:45", "17:53"},
+		{"sydney summer", "-33.8688,151.2093", "Australia/Sydney", "2024-01-01", "05:47", "20:09"},
+		{"tromso polar day", "69.6492,18.9553", "Europe/Oslo", "2024-06-21", "", ""},
+		{"tromso polar night", "69.6492,18.9553", "Europe/Oslo", "2024-12-21", "", ""},
+	} {
+		loc, err := parseLocation(tc.coords, tc.tz)
+		if err != nil {
+			t.Skipf("timezone data unavailable: %v", err)
+		}
+		day, err := time.ParseInLocation("2006-01-02", tc.day, loc.tz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rise, set, ok := loc.solarTimes(day)
+		if tc.rise == "" {
+			if ok {
+				t.Errorf("%s: solarTimes = %v, %v, want no sunrise or sunset", tc.name, rise, set)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("%s: solarTimes found no sunrise or sunset", tc.name)
+			continue
+		}
+		for _, c := range []struct {
+			event string
+			got   time.Time
+			want  string
+		}{{sunrise, rise, tc.rise}, {sunset, set, tc.set}} {
+			want, err := time.ParseInLocation("2006-01-02 15:04", tc.day+" "+c.want, loc.tz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := c.got.Sub(want); diff < -2*time.Minute || diff > 2*time.Minute {
+				t.Errorf("%s: %s = %v, want %v ±2m", tc.name, c.event, c.got.Format("15:04"), c.want)
+			}
+		}
+	}
+}
+
+func TestParseSolar(t *testing.T) {
+	loc, err := parseLocation("40.7128,-74.0060", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		spec    string
+		event   string
+		offset  time.Duration
+		dow     uint64
+		wantErr bool
+	}{
+		{spec: "@sunset", event: sunset, dow: 0x7f},
+		{spec: "@Sunrise", event: sunrise, dow: 0x7f},
+		{spec: "@sunset -30m", event: sunset, offset: -30 * time.Minute, dow: 0x7f},
+		{spec: "@sunrise +1h15m sat,sun", event: sunrise, offset: 75 * time.Minute, dow: 1<<6 | 1},
+		{spec: "@sunrise mon-fri", event: sunrise, dow: 0x3e},
+		{spec: "@sunset 7", event: sunset, dow: 1<<7 | 1},
+		{spec: "@noon", wantErr: true},
+		{spec: "@sunset -xm", wantErr: true},
+		{spec: "@sunset -30m mon sat", wantErr: true},
+		{spec: "@sunset funday", wantErr: true},
+	} {
+		s, err := parseSolar(tc.spec, loc)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseSolar(%q) error = %v, want error: %v", tc.spec, err, tc.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if s.event != tc.event || s.offset != tc.offset || s.dow != tc.dow {
+			t.Errorf("parseSolar(%q) = %s %v %b, want %s %v %b", tc.spec, s.event, s.offset, s.dow, tc.event, tc.offset, tc.dow)
+		}
+	}
+
+	if _, err := parseSolar("@sunset", &location{tz: time.UTC}); err == nil {
+		t.Errorf("parseSolar without a location succeeded, want an error")
+	}
+}
+
+func TestNextSolar(t *testing.T) {
+	loc, err := parseLocation("40.7128,-74.0060", "America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, loc.tz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	for _, tc := range []struct {
+		spec string
+		from string
+		want string // Within ±2 minutes.
+	}{
+		{"@sunset", "2024-06-21 12:00", "2024-06-21 20:31"},
+		{"@sunset", "2024-06-21 21:00", "2024-06-22 20:31"},
+		{"@sunset -30m", "2024-06-21 20:10", "2024-06-22 20:01"},
+		// Friday evening, the next weekday sunrise is on Monday.
+		{"@sunrise mon-fri", "2024-06-21 12:00", "2024-06-24 05:26"},
+	} {
+		s, err := parseSolar(tc.spec, loc)
+		if err != nil {
+			t.Fatalf("parseSolar(%q): %v", tc.spec, err)
+		}
+		got, want := s.next(at(tc.from)), at(tc.want)
+		if diff := got.Sub(want); diff < -2*time.Minute || diff > 2*time.Minute {
+			t.Errorf("%q next after %s = %v, want %v ±2m", tc.spec, tc.from, got, want)
+		}
+	}
+
+	// The sun does not set in Tromsø from late May until late July.
+	polar, err := parseLocation("69.6492,18.9553", "Europe/Oslo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	from := time.Date(2024, 6, 21, 12, 0, 0, 0, polar.tz)
+	got := polar.nextSolar(sunset, 0, 0x7f, from)
+	if got.IsZero() || got.Month() != time.July {
+		t.Errorf("next sunset in Tromsø after %v = %v, want one in late July", from, got)
+	}
+}
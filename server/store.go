@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/bamnet/apartment/wemo"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	devicesBucket  = []byte("devices")
+	statesBucket   = []byte("states")
+	settingsBucket = []byte("settings")
+	historyBucket  = []byte("history")
+)
+
+// store is the server's on-disk state: known devices with their last hosts
+// and states, the history of their changes, and user-defined settings such
+// as names, groups and rules.
+// Each setting is kept as a JSON document under its own key.
+type store struct {
+	db *bolt.DB
+}
+
+// openStore opens, or creates, the store in dir.
+func openStore(dir string) (*store, error) {
+	db, err := bolt.Open(filepath.Join(dir, "apartment.db"), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open store: %v", err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{devicesBucket, statesBucket, settingsBucket, historyBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &store{db: db}, nil
+}
+
+// load reads the setting stored under key into v.
+// A missing setting leaves v unchanged.
+func (st *store) load(key string, v interface{}) error {
+	var data []byte
+	if err := st.db.View(func(tx *bolt.Tx) error {
+		data = append([]byte(nil), tx.Bucket(settingsBucket).Get([]byte(key))...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// save stores v as the setting under key.
+func (st *store) save(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return st.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(settingsBucket).Put([]byte(key), data)
+	})
+}
+
+// devices returns the known devices and their last states, keyed by UDN.
+func (st *store) devices() (map[string]*wemo.Device, map[string]wemo.PowerState, error) {
+	devices := map[string]*wemo.Device{}
+	states := map[string]wemo.PowerState{}
+	err := st.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(devicesBucket).ForEach(func(k, v []byte) error {
+			d := &wemo.Device{}
+			if err := json.Unmarshal(v, d); err != nil {
+				return fmt.Errorf("unable to parse device %s: %v", k, err)
+			}
+			devices[string(k)] = d
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(statesBucket).ForEach(func(k, v []byte) error {
+			var state wemo.PowerState
+			if err := json.Unmarshal(v, &state); err != nil {
+				return fmt.Errorf("unable to parse state of %s: %v", k, err)
+			}
+			states[string(k)] = state
+			return nil
+		})
+	})
+	return devices, states, err
+}
+
+// putDevice records a device and its current host.
+func (st *store) putDevice(d *wemo.Device) {
+	data, err := json.Marshal(d)
+	if err == nil {
+		err = st.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(devicesBucket).Put([]byte(d.UDN), data)
+		})
+	}
+	if err != nil {
+		log.Printf("unable to store %s: %v", d.UDN, err)
+	}
+}
+
+// putState records the last known state of a device.
+func (st *store) putState(udn string, state wemo.PowerState) {
+	data, err := json.Marshal(state)
+	if err == nil {
+		err = st.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(statesBucket).Put([]byte(udn), data)
+		})
+	}
+	if err != nil {
+		log.Printf("unable to store state of %s: %v", udn, err)
+	}
+}
+
+// deleteDevice forgets a device and its state.
+func (st *store) deleteDevice(udn string) {
+	if err := st.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(devicesBucket).Delete([]byte(udn)); err != nil {
+			return err
+		}
+		return tx.Bucket(statesBucket).Delete([]byte(udn))
+	}); err != nil {
+		log.Printf("unable to forget %s: %v", udn, err)
+	}
+}
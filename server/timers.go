@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// How long until a timer which failed to revert its device tries again.
+const timerRetry = time.Minute
+
+// timers revert devices to their previous state after a while, such as a
+// fan turned on for 20 minutes. There is at most one timer per device,
+// keyed by UDN. Timers are persisted so they survive restarts.
+type timers struct {
+	store  *store
+	timers map[string]*deviceTimer
+
+	mutex *sync.Mutex
+}
+
+type deviceTimer struct {
+	Revert     bool      `json:"revert"`
+	Brightness int32     `json:"brightness,omitempty"` // Of dimmable devices.
+	Expires    time.Time `json:"expires"`
+
+	timer *time.Timer
+}
+
+// loadTimers reads the timers kept in the store. They are not started.
+func loadTimers(st *store) (*timers, error) {
+	t := &timers{
+		store:  st,
+		timers: map[string]*deviceTimer{},
+		mutex:  &sync.Mutex{},
+	}
+	if err := st.load("timers", &t.timers); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// get returns the timer of a device, if it has one.
+func (t *timers) get(udn string) *apb.Timer {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	dt, ok := t.timers[udn]
+	if !ok {
+		return nil
+	}
+	return apiTimer(udn, dt)
+}
+
+func apiTimer(udn string, dt *deviceTimer) *apb.Timer {
+	return &apb.Timer{
+		Udn:         udn,
+		RevertState: dt.Revert,
+		Expires:     dt.Expires.Unix(),
+	}
+}
+
+// startTimers starts the persisted timers. Timers which expired while the
+// server was down revert their devices right away.
+func (s *Server) startTimers() {
+	s.timers.mutex.Lock()
+	defer s.timers.mutex.Unlock()
+	for udn, dt := range s.timers.timers {
+		s.startTimer(udn, dt)
+	}
+}
+
+// startTimer arms a timer. The caller must hold the timers mutex.
+// The timer is kept until its device is reverted, a device which cannot be
+// reverted, for example while it is being located, is tried again later.
+func (s *Server) startTimer(udn string, dt *deviceTimer) {
+	dt.timer = time.AfterFunc(time.Until(dt.Expires), func() {
+		s.timers.mutex.Lock()
+		current := s.timers.timers[udn] == dt
+		s.timers.mutex.Unlock()
+		if !current {
+			return // Cancelled or replaced.
+		}
+
+		d, err := s.lookupDevice(udn)
+		if err == nil {
+			_, err = s.applyDevice(context.Background(), d, &apb.Device{State: dt.Revert, Brightness: dt.Brightness}, timerSource)
+		}
+
+		s.timers.mutex.Lock()
+		defer s.timers.mutex.Unlock()
+		if s.timers.timers[udn] != dt {
+			return // Cancelled or replaced while reverting.
+		}
+		if err != nil {
+			log.Printf("unable to revert %s after its timer, retrying in %v: %v", udn, timerRetry, err)
+			dt.Expires = time.Now().Add(timerRetry)
+			s.startTimer(udn, dt)
+		} else {
+			delete(s.timers.timers, udn)
+		}
+		if err := s.timers.store.save("timers", s.timers.timers); err != nil {
+			log.Printf("unable to save timers: %v", err)
+		}
+	})
+}
+
+// SetDeviceStateFor sets the state of a device and reverts it to its previous
+// state, and brightness if dimmable, after the given duration. Setting a
+// device which already has a timer replaces the timer, but keeps the state
+// to revert to.
+func (s *Server) SetDeviceStateFor(ctx context.Context, in *apb.SetDeviceStateForRequest) (*apb.Device, error) {
+	if in.Device == nil {
+		return nil, fmt.Errorf("a device is required")
+	}
+	if in.DurationSeconds <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+	current, err := s.GetDevice(ctx, &apb.GetDeviceRequest{Name: in.Device.Name})
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.lookupDevice(current.Udn)
+	if err != nil {
+		return nil, err
+	}
+	device, err := s.applyDevice(ctx, d, in.Device, apiSource(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	dt := &deviceTimer{
+		Revert:     current.State,
+		Brightness: current.Brightness,
+		Expires:    time.Now().Add(time.Duration(in.DurationSeconds) * time.Second),
+	}
+	s.timers.mutex.Lock()
+	defer s.timers.mutex.Unlock()
+	if old, ok := s.timers.timers[d.UDN]; ok {
+		old.timer.Stop()
+		dt.Revert = old.Revert
+		dt.Brightness = old.Brightness
+	}
+	s.timers.timers[d.UDN] = dt
+	s.startTimer(d.UDN, dt)
+	if err := s.timers.store.save("timers", s.timers.timers); err != nil {
+		return nil, err
+	}
+	device.Timer = apiTimer(d.UDN, dt)
+	return device, nil
+}
+
+// ListTimers lists the pending timers, soonest first.
+func (s *Server) ListTimers(ctx context.Context, _ *apb.ListTimersRequest) (*apb.ListTimersResponse, error) {
+	s.timers.mutex.Lock()
+	defer s.timers.mutex.Unlock()
+	list := []*apb.Timer{}
+	for udn, dt := range s.timers.timers {
+		list = append(list, apiTimer(udn, dt))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Expires < list[j].Expires })
+	return &apb.ListTimersResponse{Timer: list}, nil
+}
+
+// CancelTimer cancels the timer of a device, leaving it in its current state.
+func (s *Server) CancelTimer(ctx context.Context, in *apb.CancelTimerRequest) (*apb.CancelTimerResponse, error) {
+	d, err := s.lookupDevice(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.timers.cancel(d.UDN)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no timer found")
+	}
+	return &apb.CancelTimerResponse{}, nil
+}
+
+// cancel stops the timer of a device, reporting whether it had one.
+func (t *timers) cancel(udn string) (bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	dt, ok := t.timers[udn]
+	if !ok {
+		return false, nil
+	}
+	dt.timer.Stop()
+	delete(t.timers, udn)
+	return true, t.store.save("timers", t.timers)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log"
+
+	apb "github.com/bamnet/apartment/proto/apartment"
+)
+
+// Number of events a watcher may fall behind before events are dropped.
+const watchBuffer = 32
+
+// WatchDevices streams device discovery, removal and state change events
+// until the client goes away.
+func (s *Server) WatchDevices(_ *apb.WatchDevicesRequest, stream apb.Apartment_WatchDevicesServer) error {
+	events, stop := s.watch()
+	defer stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-events:
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watch registers a new watcher, for the WatchDevices RPC or any server
+// feature reacting to device changes (such as motion sensors triggering).
+// The returned func must be called to unregister it.
+func (s *Server) watch() (<-chan *apb.DeviceEvent, func()) {
+	events := make(chan *apb.DeviceEvent, watchBuffer)
+	s.mutex.Lock()
+	s.watchers[events] = true
+	s.mutex.Unlock()
+
+	return events, func() {
+		s.mutex.Lock()
+		delete(s.watchers, events)
+		s.mutex.Unlock()
+	}
+}
+
+// publish sends an event to every watcher.
+// The caller must hold the mutex.
+func (s *Server) publish(t apb.DeviceEvent_Type, device *apb.Device) {
+	e := &apb.DeviceEvent{
+		Type:   t,
+		Device: device,
+	}
+	for w := range s.watchers {
+		select {
+		case w <- e:
+		default:
+			log.Printf("watcher is falling behind, dropping %s event for %s", t, device.Name)
+		}
+	}
+}
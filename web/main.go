@@ -7,6 +7,9 @@ import (
 	"log"
 	"net/http"
 	"sort"
+	"strconv"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -16,11 +19,17 @@ import (
 var (
 	srvAddr = flag.String("apt_server", "localhost:10000", "Host of the apartment server.")
 
-	templates = template.Must(template.ParseFiles("index.html"))
+	templates = template.Must(template.New("index.html").Funcs(template.FuncMap{
+		"stateClass": stateClass,
+	}).ParseFiles("index.html"))
 )
 
 var client apb.ApartmentClient
 
+// How long the index page waits for the brightness of dimmable devices.
+// Devices which do not answer in time are shown without it.
+const detailTimeout = 2 * time.Second
+
 // ByFriendlyName sorts devices by their friendly name.
 type ByFriendlyName []*apb.Device
 
@@ -34,6 +43,18 @@ func (s ByFriendlyName) Less(i, j int) bool {
 	return s[i].FriendlyName < s[j].FriendlyName
 }
 
+// stateClass picks the button style for a device state so standby devices
+// stand out from those that are fully on.
+func stateClass(state apb.PowerState) string {
+	switch state {
+	case apb.PowerState_ON:
+		return "mdl-button--colored"
+	case apb.PowerState_STANDBY:
+		return "mdl-button--accent"
+	}
+	return ""
+}
+
 func toggleHandler(w http.ResponseWriter, r *http.Request) {
 	name := r.URL.Query().Get("name")
 	if name == "" {
@@ -58,15 +79,149 @@ func toggleHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/", http.StatusFound)
 }
 
+func renameHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	friendlyName := r.URL.Query().Get("friendly_name")
+	if name == "" || friendlyName == "" {
+		http.Error(w, "missing name or friendly_name param", http.StatusBadRequest)
+		return
+	}
+	log.Printf("renaming %s to %s", name, friendlyName)
+	if _, err := client.RenameDevice(context.Background(), &apb.RenameDeviceRequest{
+		Name:         name,
+		FriendlyName: friendlyName,
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func dimHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing name param", http.StatusBadRequest)
+		return
+	}
+	level, err := strconv.Atoi(r.URL.Query().Get("level"))
+	if err != nil || level < 0 || level > 100 {
+		http.Error(w, "level must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+	log.Printf("dimming %s to %d", name, level)
+	d, err := client.GetDevice(context.Background(), &apb.GetDeviceRequest{Name: name})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	d.State = level > 0
+	d.Brightness = int32(level)
+	d, err = client.UpdateDevice(context.Background(), &apb.UpdateDeviceRequest{
+		Device: d,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func timerHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing name param", http.StatusBadRequest)
+		return
+	}
+	minutes, err := strconv.Atoi(r.URL.Query().Get("minutes"))
+	if err != nil || minutes <= 0 {
+		http.Error(w, "minutes must be a positive number", http.StatusBadRequest)
+		return
+	}
+	d, err := client.GetDevice(context.Background(), &apb.GetDeviceRequest{Name: name})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("toggling %s for %d minutes", name, minutes)
+	d.State = !d.State
+	if _, err := client.SetDeviceStateFor(context.Background(), &apb.SetDeviceStateForRequest{
+		Device:          d,
+		DurationSeconds: int64(minutes) * 60,
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func cancelTimerHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing name param", http.StatusBadRequest)
+		return
+	}
+	log.Printf("cancelling timer for: %s", name)
+	if _, err := client.CancelTimer(context.Background(), &apb.CancelTimerRequest{Name: name}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func sceneHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing name param", http.StatusBadRequest)
+		return
+	}
+	log.Printf("activating scene: %s", name)
+	resp, err := client.ActivateScene(context.Background(), &apb.ActivateSceneRequest{Name: name})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, res := range resp.Result {
+		if !res.Success {
+			log.Printf("scene %s: %s failed: %s", name, res.Name, res.Error)
+		}
+	}
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
 func indexHandler(w http.ResponseWriter, r *http.Request) {
 	p := struct {
 		Devices []*apb.Device
+		Scenes  []*apb.Scene
 	}{}
 
 	resp, _ := client.ListDevices(context.Background(), &apb.ListDevicesRequest{})
 	p.Devices = resp.Device
 	sort.Sort(ByFriendlyName(p.Devices))
 
+	if scenes, err := client.ListScenes(context.Background(), &apb.ListScenesRequest{}); err == nil {
+		p.Scenes = scenes.Scene
+	}
+
+	// The device list does not include brightness, fetch it for the sliders.
+	ctx, cancel := context.WithTimeout(r.Context(), detailTimeout)
+	defer cancel()
+	wg := &sync.WaitGroup{}
+	for i, d := range p.Devices {
+		if !d.Dimmable {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			if full, err := client.GetDevice(ctx, &apb.GetDeviceRequest{Name: name}); err == nil {
+				p.Devices[i] = full
+			}
+		}(i, d.Name)
+	}
+	wg.Wait()
+
 	if err := templates.ExecuteTemplate(w, "index.html", p); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -87,6 +242,11 @@ func main() {
 	http.Handle("/node_modules/", http.StripPrefix("/node_modules/", http.FileServer(http.Dir("./node_modules"))))
 	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./static"))))
 	http.HandleFunc("/toggle", toggleHandler)
+	http.HandleFunc("/dim", dimHandler)
+	http.HandleFunc("/rename", renameHandler)
+	http.HandleFunc("/scene", sceneHandler)
+	http.HandleFunc("/timer", timerHandler)
+	http.HandleFunc("/cancel_timer", cancelTimerHandler)
 	http.HandleFunc("/", indexHandler)
 	http.ListenAndServe(":8080", nil)
 }
@@ -0,0 +1,217 @@
+package wemo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const bridgeService = "urn:Belkin:service:bridge:1"
+
+// Capabilities of bulbs paired with a WeMo Link bridge.
+const (
+	capOnOff     = "10006"
+	capLevel     = "10008"
+	capColorTemp = "30301"
+)
+
+// bulb identifies an end device behind a WeMo Link bridge.
+type bulb struct {
+	bridgeUDN string
+	id        string
+	dimmable  bool
+	tunable   bool
+}
+
+// IsBulb reports whether the Device is a bulb behind a WeMo Link bridge.
+// Bulbs are reached through the bridge, so their Host is the bridge's.
+func (d *Device) IsBulb() bool {
+	return d.bulb != nil
+}
+
+// Evented reports whether the Device can push state changes to a Subscriber.
+func (d *Device) Evented() bool {
+	return !d.IsBulb()
+}
+
+// Tunable reports whether the Device supports setting a color temperature.
+func (d *Device) Tunable() bool {
+	return d.bulb != nil && d.bulb.tunable
+}
+
+var deviceListsRe = regexp.MustCompile(`(?s)<DeviceLists>(.*)</DeviceLists>`)
+
+// Bulbs lists the bulbs paired with a WeMo Link bridge.
+func (d *Device) Bulbs() ([]*Device, error) {
+	if d.DeviceType != BridgeType {
+		return nil, fmt.Errorf("%s is not a bridge", d.FriendlyName)
+	}
+	args := fmt.Sprintf("<DevUDN>%s</DevUDN><ReqListType>PAIRED_LIST</ReqListType>", xmlEscape(d.UDN))
+	body, err := d.call("bridge1", bridgeService, "GetEndDevices", args)
+	if err != nil {
+		return nil, err
+	}
+	return d.parseBulbs(body)
+}
+
+// parseBulbs parses a GetEndDevices response from the bridge into its bulbs.
+// The device list is an XML document escaped inside the SOAP response.
+func (d *Device) parseBulbs(body []byte) ([]*Device, error) {
+	matches := deviceListsRe.FindSubmatch(body)
+	if matches == nil {
+		return nil, fmt.Errorf("no device list in response from %s", d.Host)
+	}
+	var list string
+	if err := xml.Unmarshal([]byte("<a>"+string(matches[1])+"</a>"), &list); err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Devices []struct {
+			ID              string `xml:"DeviceID"`
+			FriendlyName    string `xml:"FriendlyName"`
+			Capabilities    string `xml:"CapabilityIDs"`
+			FirmwareVersion string `xml:"FirmwareVersion"`
+			ModelCode       string `xml:"ModelCode"`
+		} `xml:"DeviceList>DeviceInfos>DeviceInfo"`
+	}{}
+	if err := xml.Unmarshal([]byte(list), &data); err != nil {
+		return nil, err
+	}
+
+	bulbs := []*Device{}
+	for _, info := range data.Devices {
+		caps := strings.Split(info.Capabilities, ",")
+		bulbs = append(bulbs, &Device{
+			Host:            d.Host,
+			FriendlyName:    info.FriendlyName,
+			DeviceType:      BulbType,
+			UDN:             d.UDN + ":" + info.ID,
+			SerialNumber:    info.ID,
+			ModelName:       info.ModelCode,
+			FirmwareVersion: info.FirmwareVersion,
+			bulb: &bulb{
+				bridgeUDN: d.UDN,
+				id:        info.ID,
+				dimmable:  contains(caps, capLevel),
+				tunable:   contains(caps, capColorTemp),
+			},
+		})
+	}
+	return bulbs, nil
+}
+
+var deviceStatusListRe = regexp.MustCompile(`(?s)<DeviceStatusList>(.*)</DeviceStatusList>`)
+
+// bulbStatus reads the current value of each capability of a bulb.
+func (d *Device) bulbStatus() (map[string]string, error) {
+	args := fmt.Sprintf("<DeviceIDs>%s</DeviceIDs>", xmlEscape(d.bulb.id))
+	body, err := d.call("bridge1", bridgeService, "GetDeviceStatus", args)
+	if err != nil {
+		return nil, err
+	}
+	matches := deviceStatusListRe.FindSubmatch(body)
+	if matches == nil {
+		return nil, fmt.Errorf("no status in response from %s", d.Host)
+	}
+	var list string
+	if err := xml.Unmarshal([]byte("<a>"+string(matches[1])+"</a>"), &list); err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Capabilities string `xml:"DeviceStatus>CapabilityID"`
+		Values       string `xml:"DeviceStatus>CapabilityValue"`
+	}{}
+	if err := xml.Unmarshal([]byte(list), &data); err != nil {
+		return nil, err
+	}
+
+	status := map[string]string{}
+	values := strings.Split(data.Values, ",")
+	for i, c := range strings.Split(data.Capabilities, ",") {
+		if i < len(values) {
+			status[c] = values[i]
+		}
+	}
+	return status, nil
+}
+
+// setBulbStatus sets one capability of a bulb.
+func (d *Device) setBulbStatus(capability, value string) error {
+	status := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>`+
+		`<DeviceStatus><IsGroupAction>NO</IsGroupAction>`+
+		`<DeviceID available="YES">%s</DeviceID>`+
+		`<CapabilityID>%s</CapabilityID><CapabilityValue>%s</CapabilityValue>`+
+		`</DeviceStatus>`, d.bulb.id, capability, value)
+	args := "<DeviceStatusList>" + xmlEscape(status) + "</DeviceStatusList>"
+	_, err := d.call("bridge1", bridgeService, "SetDeviceStatus", args)
+	return err
+}
+
+// bulbPowerState reads whether a bulb is on.
+func (d *Device) bulbPowerState() (PowerState, error) {
+	status, err := d.bulbStatus()
+	if err != nil {
+		return Unknown, err
+	}
+	v, ok := status[capOnOff]
+	if !ok || v == "" {
+		// Bulbs which lost power report no state.
+		return Unknown, fmt.Errorf("%s is unavailable", d.FriendlyName)
+	}
+	return parseBinaryState(v)
+}
+
+// bulbLevel reads the first number of a "value:transition" capability.
+func (d *Device) bulbLevel(capability string) (int, error) {
+	status, err := d.bulbStatus()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := status[capability]
+	if !ok || v == "" {
+		return 0, fmt.Errorf("%s is unavailable", d.FriendlyName)
+	}
+	return strconv.Atoi(strings.SplitN(v, ":", 2)[0])
+}
+
+// ColorTemperature gets the color temperature of a tunable bulb, in Kelvin.
+func (d *Device) ColorTemperature() (int, error) {
+	if !d.Tunable() {
+		return 0, fmt.Errorf("%s is not tunable", d.FriendlyName)
+	}
+	mireds, err := d.bulbLevel(capColorTemp)
+	if err != nil {
+		return 0, err
+	}
+	if mireds == 0 {
+		return 0, fmt.Errorf("invalid color temperature from %s", d.FriendlyName)
+	}
+	return int(math.Round(1e6 / float64(mireds))), nil
+}
+
+// SetColorTemperature sets the color temperature of a tunable bulb, in Kelvin.
+// WeMo bulbs support roughly 2700K to 6500K.
+func (d *Device) SetColorTemperature(kelvin int) error {
+	if !d.Tunable() {
+		return fmt.Errorf("%s is not tunable", d.FriendlyName)
+	}
+	if kelvin <= 0 {
+		return fmt.Errorf("color temperature %d out of range", kelvin)
+	}
+	mireds := int(math.Round(1e6 / float64(kelvin)))
+	return d.setBulbStatus(capColorTemp, fmt.Sprintf("%d:0", mireds))
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package wemo
+
+import (
+	"html"
+	"reflect"
+	"testing"
+)
+
+func TestParseBulbs(t *testing.T) {
+	bridge := &Device{
+		Host:       "192.168.1.20:49153",
+		DeviceType: BridgeType,
+		UDN:        "uuid:Bridge-1_0-231419B0100001",
+	}
+	list := `<?xml version="1.0" encoding="utf-8"?>` +
+		`<DeviceLists><DeviceList><DeviceListType>Paired</DeviceListType><DeviceInfos>` +
+		`<DeviceInfo><DeviceIndex>0</DeviceIndex><DeviceID>94103EA2B27803ED</DeviceID>` +
+		`<FriendlyName>Hallway</FriendlyName><FirmwareVersion>7E</FirmwareVersion>` +
+		`<CapabilityIDs>10006,10008,30008,30009,3000A</CapabilityIDs><ModelCode>LIGHTIFY A19 Tunable White</ModelCode></DeviceInfo>` +
+		`<DeviceInfo><DeviceIndex>1</DeviceIndex><DeviceID>94103EA2B278EA8B</DeviceID>` +
+		`<FriendlyName>Desk</FriendlyName><FirmwareVersion>83</FirmwareVersion>` +
+		`<CapabilityIDs>10006,10008,30008,30009,3000A,30301</CapabilityIDs><ModelCode>MZ100</ModelCode></DeviceInfo>` +
+		`</DeviceInfos></DeviceList></DeviceLists>`
+	body := `<s:Envelope><s:Body><u:GetEndDevicesResponse xmlns:u="urn:Belkin:service:bridge:1">` +
+		`<DeviceLists>` + html.EscapeString(list) + `</DeviceLists>` +
+		`</u:GetEndDevicesResponse></s:Body></s:Envelope>`
+
+	got, err := bridge.parseBulbs([]byte(body))
+	if err != nil {
+		t.Fatalf("parseBulbs() error = %v", err)
+	}
+	want := []*Device{
+		{
+			Host:            bridge.Host,
+			FriendlyName:    "Hallway",
+			DeviceType:      BulbType,
+			UDN:             bridge.UDN + ":94103EA2B27803ED",
+			SerialNumber:    "94103EA2B27803ED",
+			ModelName:       "LIGHTIFY A19 Tunable White",
+			FirmwareVersion: "7E",
+			bulb:            &bulb{bridgeUDN: bridge.UDN, id: "94103EA2B27803ED", dimmable: true},
+		},
+		{
+			Host:            bridge.Host,
+			FriendlyName:    "Desk",
+			DeviceType:      BulbType,
+			UDN:             bridge.UDN + ":94103EA2B278EA8B",
+			SerialNumber:    "94103EA2B278EA8B",
+			ModelName:       "MZ100",
+			FirmwareVersion: "83",
+			bulb:            &bulb{bridgeUDN: bridge.UDN, id: "94103EA2B278EA8B", dimmable: true, tunable: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBulbs() = %+v, want %+v", got, want)
+	}
+
+	empty := `<DeviceLists>` + html.EscapeString(`<DeviceLists><DeviceList><DeviceInfos/></DeviceList></DeviceLists>`) + `</DeviceLists>`
+	if got, err := bridge.parseBulbs([]byte(empty)); err != nil || len(got) != 0 {
+		t.Errorf("parseBulbs() with no bulbs = %v, %v, want none", got, err)
+	}
+	if _, err := bridge.parseBulbs([]byte(`<s:Envelope></s:Envelope>`)); err == nil {
+		t.Errorf("parseBulbs() without a device list succeeded, want an error")
+	}
+}
@@ -0,0 +1,65 @@
+package wemo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+var brightnessRe = regexp.MustCompile(`<brightness>(\d+)</brightness>`)
+
+// Dimmable reports whether the Device supports brightness levels.
+func (d *Device) Dimmable() bool {
+	return d.DeviceType == DimmerType || d.bulb != nil && d.bulb.dimmable
+}
+
+// Brightness gets the brightness of a dimmer, from 0 to 100.
+func (d *Device) Brightness() (int, error) {
+	if !d.Dimmable() {
+		return 0, fmt.Errorf("%s is not dimmable", d.FriendlyName)
+	}
+	if d.IsBulb() {
+		// Bulbs use levels from 0 to 255.
+		level, err := d.bulbLevel(capLevel)
+		return int(math.Round(float64(level) * 100 / 255)), err
+	}
+	body, err := d.call("basicevent1", basicEventService, "GetBinaryState", "")
+	if err != nil {
+		return 0, err
+	}
+
+	matches := brightnessRe.FindStringSubmatch(string(body))
+	if matches == nil {
+		return 0, fmt.Errorf("no brightness in response from %s", d.Host)
+	}
+	return strconv.Atoi(matches[1])
+}
+
+// SetBrightness turns a dimmer on at a brightness from 1 to 100.
+// A brightness of 0 turns it off.
+func (d *Device) SetBrightness(brightness int) error {
+	if !d.Dimmable() {
+		return fmt.Errorf("%s is not dimmable", d.FriendlyName)
+	}
+	if brightness < 0 || brightness > 100 {
+		return fmt.Errorf("brightness %d out of range", brightness)
+	}
+	if d.IsBulb() {
+		if brightness == 0 {
+			return d.SetState(false)
+		}
+		if err := d.SetState(true); err != nil {
+			return err
+		}
+		level := int(math.Round(float64(brightness) * 255 / 100))
+		return d.setBulbStatus(capLevel, fmt.Sprintf("%d:0", level))
+	}
+	state := 0
+	if brightness > 0 {
+		state = 1
+	}
+	args := fmt.Sprintf("<BinaryState>%d</BinaryState><brightness>%d</brightness>", state, brightness)
+	_, err := d.call("basicevent1", basicEventService, "SetBinaryState", args)
+	return err
+}
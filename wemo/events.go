@@ -0,0 +1,317 @@
+package wemo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	eventURL = "http://%s/upnp/event/basicevent1"
+
+	// Requested lifetime of a subscription, in seconds.
+	subscriptionTimeout = 600
+)
+
+// eventClient sends subscription requests, which must not hang on devices
+// which went away.
+var eventClient = &http.Client{Timeout: 5 * time.Second}
+
+// Event is a state change pushed by a subscribed Device.
+type Event struct {
+	Device *Device
+	State  PowerState
+}
+
+// Subscriber receives UPnP GENA event notifications from WeMo devices.
+// It runs a small HTTP server that devices NOTIFY when their BinaryState
+// changes, for example when someone presses the physical button.
+type Subscriber struct {
+	listener net.Listener
+	events   chan Event
+
+	mutex  *sync.Mutex
+	nextID int
+	subs   map[string]*subscription // Keyed by callback path.
+}
+
+type subscription struct {
+	device *Device
+	path   string
+	sid    string
+	stop   chan struct{}
+}
+
+// NewSubscriber starts listening for event notifications on addr.
+// Use ":0" to pick any free port.
+func NewSubscriber(addr string) (*Subscriber, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &Subscriber{
+		listener: lis,
+		events:   make(chan Event, 16),
+		mutex:    &sync.Mutex{},
+		subs:     map[string]*subscription{},
+	}
+	go http.Serve(lis, s)
+	return s, nil
+}
+
+// Events returns the channel state change events are delivered on.
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+// Subscribe asks a Device to send its state changes to the Subscriber.
+// The subscription is renewed in the background until Unsubscribe is called.
+func (s *Subscriber) Subscribe(d *Device) error {
+	if !d.Evented() {
+		return fmt.Errorf("%s does not support events", d.FriendlyName)
+	}
+	s.mutex.Lock()
+	s.nextID++
+	sub := &subscription{
+		device: d,
+		path:   fmt.Sprintf("/event/%d", s.nextID),
+		stop:   make(chan struct{}),
+	}
+	// Register before subscribing, devices send the initial state right away.
+	s.subs[sub.path] = sub
+	s.mutex.Unlock()
+
+	timeout, err := s.subscribe(sub)
+	if err != nil {
+		s.mutex.Lock()
+		delete(s.subs, sub.path)
+		s.mutex.Unlock()
+		return err
+	}
+	go s.renew(sub, timeout)
+	return nil
+}
+
+// Unsubscribe stops receiving events from a Device.
+func (s *Subscriber) Unsubscribe(d *Device) error {
+	s.mutex.Lock()
+	var sub *subscription
+	for path, ss := range s.subs {
+		if ss.device == d {
+			sub = ss
+			delete(s.subs, path)
+			break
+		}
+	}
+	if sub == nil {
+		s.mutex.Unlock()
+		return fmt.Errorf("not subscribed to %s", d.Host)
+	}
+	sid := sub.sid
+	s.mutex.Unlock()
+	close(sub.stop)
+
+	req, err := http.NewRequest("UNSUBSCRIBE", fmt.Sprintf(eventURL, d.Host), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("SID", sid)
+	resp, err := eventClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Replace moves the subscription of a Device over to d, another value for
+// the same device at the same host such as a renamed copy, without
+// contacting the device. Events are then delivered for d.
+func (s *Subscriber) Replace(old, d *Device) error {
+	if old.Host != d.Host {
+		return fmt.Errorf("%s moved, subscribe again instead", d.FriendlyName)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, sub := range s.subs {
+		if sub.device == old {
+			sub.device = d
+			return nil
+		}
+	}
+	return fmt.Errorf("not subscribed to %s", old.Host)
+}
+
+// Subscribed reports whether the Subscriber is receiving events from a
+// Device: the device accepted the subscription and it has not lapsed since.
+func (s *Subscriber) Subscribed(d *Device) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, sub := range s.subs {
+		if sub.device == d {
+			return sub.sid != ""
+		}
+	}
+	return false
+}
+
+// Close stops the notification listener.
+// Devices stop sending events once their subscriptions expire.
+func (s *Subscriber) Close() error {
+	s.mutex.Lock()
+	for path, sub := range s.subs {
+		close(sub.stop)
+		delete(s.subs, path)
+	}
+	s.mutex.Unlock()
+	return s.listener.Close()
+}
+
+// subscribe creates or renews a subscription, returning how long it lasts.
+func (s *Subscriber) subscribe(sub *subscription) (time.Duration, error) {
+	host := s.host(sub)
+	req, err := http.NewRequest("SUBSCRIBE", fmt.Sprintf(eventURL, host), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("TIMEOUT", fmt.Sprintf("Second-%d", subscriptionTimeout))
+	if sub.sid != "" {
+		req.Header.Set("SID", sub.sid)
+	} else {
+		callback, err := s.callbackURL(host, sub.path)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("CALLBACK", fmt.Sprintf("<%s>", callback))
+		req.Header.Set("NT", "upnp:event")
+	}
+
+	resp, err := eventClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("subscribe to %s failed: %s", host, resp.Status)
+	}
+
+	s.mutex.Lock()
+	sub.sid = resp.Header.Get("SID")
+	s.mutex.Unlock()
+	return parseTimeout(resp.Header.Get("TIMEOUT")), nil
+}
+
+// renew keeps a subscription alive by renewing it halfway through its
+// lifetime. If a renewal fails, a fresh subscription is attempted.
+func (s *Subscriber) renew(sub *subscription, timeout time.Duration) {
+	for {
+		select {
+		case <-sub.stop:
+			return
+		case <-time.After(timeout / 2):
+		}
+
+		t, err := s.subscribe(sub)
+		if err != nil {
+			log.Printf("unable to renew subscription to %s: %v", s.host(sub), err)
+			s.mutex.Lock()
+			sub.sid = ""
+			s.mutex.Unlock()
+			if t, err = s.subscribe(sub); err != nil {
+				log.Printf("unable to resubscribe to %s: %v", s.host(sub), err)
+				t = 30 * time.Second // Try again soon.
+			}
+		}
+		timeout = t
+	}
+}
+
+// host returns the address of the device of a subscription.
+func (s *Subscriber) host(sub *subscription) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return sub.device.Host
+}
+
+// callbackURL builds the URL a device at host should send notifications to
+// on path, using the local address the device can reach us on.
+func (s *Subscriber) callbackURL(host, path string) (string, error) {
+	conn, err := net.Dial("udp", host)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	ip := conn.LocalAddr().(*net.UDPAddr).IP
+	port := s.listener.Addr().(*net.TCPAddr).Port
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(ip.String(), strconv.Itoa(port)), path), nil
+}
+
+type propertySet struct {
+	Properties []struct {
+		BinaryState *string `xml:"BinaryState"`
+	} `xml:"property"`
+}
+
+// ServeHTTP handles NOTIFY requests from devices.
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "NOTIFY" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.mutex.Lock()
+	sub, ok := s.subs[r.URL.Path]
+	var d *Device
+	if ok {
+		d = sub.device
+	}
+	s.mutex.Unlock()
+	if !ok {
+		http.Error(w, "unknown subscription", http.StatusPreconditionFailed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+
+	props := propertySet{}
+	if err := xml.Unmarshal(body, &props); err != nil {
+		log.Printf("unable to parse event from %s: %v", d.Host, err)
+		return
+	}
+	for _, p := range props.Properties {
+		if p.BinaryState == nil {
+			continue
+		}
+		state, err := parseBinaryState(*p.BinaryState)
+		if err != nil {
+			log.Printf("unable to parse state from %s: %v", d.Host, err)
+			continue
+		}
+		select {
+		case s.events <- Event{Device: d, State: state}:
+		case <-sub.stop:
+			return
+		}
+	}
+}
+
+// parseTimeout parses a "Second-N" TIMEOUT header, defaulting to the
+// requested timeout if the device sends something unexpected.
+func parseTimeout(h string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimPrefix(h, "Second-"))
+	if err != nil || secs <= 0 {
+		secs = subscriptionTimeout
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,75 @@
+package wemo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const insightService = "urn:Belkin:service:insight:1"
+
+// InsightParams holds the energy readings of an Insight switch.
+type InsightParams struct {
+	CurrentPower     int           // Instantaneous power draw in mW.
+	TodayKWh         float64       // Energy used today.
+	TotalKWh         float64       // Energy used over the tracking period.
+	OnToday          time.Duration // Time spent on today.
+	StandbyThreshold int           // Power draw in mW below which the device is in standby.
+}
+
+var insightParamsRe = regexp.MustCompile(`<InsightParams>([^<]*)</InsightParams>`)
+
+// InsightParams reads the current energy metering values of an Insight switch.
+func (d *Device) InsightParams() (*InsightParams, error) {
+	body, err := d.call("insight1", insightService, "GetInsightParams", "")
+	if err != nil {
+		return nil, err
+	}
+	matches := insightParamsRe.FindStringSubmatch(string(body))
+	if matches == nil {
+		return nil, fmt.Errorf("no insight params in response from %s", d.Host)
+	}
+	return parseInsightParams(matches[1])
+}
+
+// parseInsightParams parses the pipe separated GetInsightParams payload:
+// state|lastchange|onfor|ontoday|ontotal|period|unknown|currentmw|todaymw|totalmw|threshold
+// where todaymw and totalmw are measured in mW-minutes.
+func parseInsightParams(s string) (*InsightParams, error) {
+	fields := strings.Split(s, "|")
+	if len(fields) < 11 {
+		return nil, fmt.Errorf("malformed insight params %q", s)
+	}
+
+	onToday, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return nil, err
+	}
+	current, err := strconv.Atoi(fields[7])
+	if err != nil {
+		return nil, err
+	}
+	today, err := strconv.ParseFloat(fields[8], 64)
+	if err != nil {
+		return nil, err
+	}
+	total, err := strconv.ParseFloat(fields[9], 64)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := strconv.Atoi(fields[10])
+	if err != nil {
+		return nil, err
+	}
+
+	const mWMinPerKWh = 1000 * 1000 * 60
+	return &InsightParams{
+		CurrentPower:     current,
+		TodayKWh:         today / mWMinPerKWh,
+		TotalKWh:         total / mWMinPerKWh,
+		OnToday:          time.Duration(onToday) * time.Second,
+		StandbyThreshold: threshold,
+	}, nil
+}
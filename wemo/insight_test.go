@@ -0,0 +1,54 @@
+package wemo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseInsightParams(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    *InsightParams
+		wantErr bool
+	}{
+		{
+			in: "8|1490000000|20|3600|56789|1209600|10|3000|120000000|6000000000|8000",
+			want: &InsightParams{
+				CurrentPower:     3000,
+				TodayKWh:         2,
+				TotalKWh:         100,
+				OnToday:          time.Hour,
+				StandbyThreshold: 8000,
+			},
+		},
+		{
+			in:   "0|1490000000|0|0|0|1209600|0|0|0|0|8000",
+			want: &InsightParams{StandbyThreshold: 8000},
+		},
+		// Some firmware reports the totals with a decimal point.
+		{
+			in: "1|1490000000|20|60|120|1209600|10|45500|60000.0|1500000.5|8000",
+			want: &InsightParams{
+				CurrentPower:     45500,
+				TodayKWh:         60000.0 / 6e7,
+				TotalKWh:         1500000.5 / 6e7,
+				OnToday:          time.Minute,
+				StandbyThreshold: 8000,
+			},
+		},
+		{in: "", wantErr: true},
+		{in: "1|1490000000|0|0|0|1209600|0|0|0|0", wantErr: true},
+		{in: "1|1490000000|0|x|0|1209600|0|0|0|0|8000", wantErr: true},
+		{in: "1|1490000000|0|0|0|1209600|0|0|0|0|", wantErr: true},
+	} {
+		got, err := parseInsightParams(tc.in)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseInsightParams(%q) error = %v, want error: %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseInsightParams(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
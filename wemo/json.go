@@ -0,0 +1,82 @@
+package wemo
+
+import "encoding/json"
+
+// deviceJSON is the serialized form of a Device, including how to reach
+// bulbs through their bridge.
+type deviceJSON struct {
+	Host            string `json:"host"`
+	FriendlyName    string `json:"friendly_name"`
+	DeviceType      string `json:"device_type"`
+	UDN             string `json:"udn"`
+	SerialNumber    string `json:"serial_number,omitempty"`
+	MACAddress      string `json:"mac_address,omitempty"`
+	ModelName       string `json:"model_name,omitempty"`
+	ModelNumber     string `json:"model_number,omitempty"`
+	FirmwareVersion string `json:"firmware_version,omitempty"`
+	IconURL         string `json:"icon_url,omitempty"`
+
+	Bulb *bulbJSON `json:"bulb,omitempty"`
+}
+
+type bulbJSON struct {
+	BridgeUDN string `json:"bridge_udn"`
+	ID        string `json:"id"`
+	Dimmable  bool   `json:"dimmable,omitempty"`
+	Tunable   bool   `json:"tunable,omitempty"`
+}
+
+// MarshalJSON encodes the Device so it can be stored and restored later
+// without connecting to it.
+func (d *Device) MarshalJSON() ([]byte, error) {
+	j := deviceJSON{
+		Host:            d.Host,
+		FriendlyName:    d.FriendlyName,
+		DeviceType:      d.DeviceType,
+		UDN:             d.UDN,
+		SerialNumber:    d.SerialNumber,
+		MACAddress:      d.MACAddress,
+		ModelName:       d.ModelName,
+		ModelNumber:     d.ModelNumber,
+		FirmwareVersion: d.FirmwareVersion,
+		IconURL:         d.IconURL,
+	}
+	if d.bulb != nil {
+		j.Bulb = &bulbJSON{
+			BridgeUDN: d.bulb.bridgeUDN,
+			ID:        d.bulb.id,
+			Dimmable:  d.bulb.dimmable,
+			Tunable:   d.bulb.tunable,
+		}
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes a Device encoded by MarshalJSON.
+func (d *Device) UnmarshalJSON(data []byte) error {
+	j := deviceJSON{}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*d = Device{
+		Host:            j.Host,
+		FriendlyName:    j.FriendlyName,
+		DeviceType:      j.DeviceType,
+		UDN:             j.UDN,
+		SerialNumber:    j.SerialNumber,
+		MACAddress:      j.MACAddress,
+		ModelName:       j.ModelName,
+		ModelNumber:     j.ModelNumber,
+		FirmwareVersion: j.FirmwareVersion,
+		IconURL:         j.IconURL,
+	}
+	if j.Bulb != nil {
+		d.bulb = &bulb{
+			bridgeUDN: j.Bulb.BridgeUDN,
+			id:        j.Bulb.ID,
+			dimmable:  j.Bulb.Dimmable,
+			tunable:   j.Bulb.Tunable,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package wemo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/huin/goupnp"
+)
+
+// Ports WeMo devices listen on. Devices hop between them when they restart.
+var ports = []int{49152, 49153, 49154, 49155}
+
+// Locate finds the current address of the Device, which may have changed
+// since it was discovered. The known ports on the last address are tried
+// first, then the network is searched for the Device's UDN.
+// A new Device is returned, d itself is left unchanged.
+func (d *Device) Locate() (*Device, error) {
+	return d.LocateContext(context.Background())
+}
+
+// LocateContext is Locate, giving up once ctx is done. A step in progress
+// when it is done runs to its own timeout first.
+func (d *Device) LocateContext(ctx context.Context) (*Device, error) {
+	udn := d.UDN
+	if d.IsBulb() {
+		udn = d.bulb.bridgeUDN // Bulbs move with their bridge.
+	}
+
+	found, err := locate(ctx, udn, d.Host)
+	if err != nil {
+		return nil, err
+	}
+	if !d.IsBulb() {
+		return found, nil
+	}
+
+	bulbs, err := found.Bulbs()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bulbs {
+		if b.UDN == d.UDN {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is no longer paired with its bridge", d.FriendlyName)
+}
+
+func locate(ctx context.Context, udn, lastHost string) (*Device, error) {
+	if ip, _, err := net.SplitHostPort(lastHost); err == nil {
+		for _, port := range ports {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			d, err := NewDevice(net.JoinHostPort(ip, strconv.Itoa(port)))
+			if err == nil && d.UDN == udn {
+				return d, nil
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	hosts, err := goupnp.DiscoverDevices(udn)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		d, err := NewDevice(host.Location.Host)
+		if err == nil && d.UDN == udn {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to locate %s", udn)
+}
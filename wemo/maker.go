@@ -0,0 +1,96 @@
+package wemo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const deviceEventService = "urn:Belkin:service:deviceevent:1"
+
+// MakerAttributes describes the relay and sensor input of a WeMo Maker.
+type MakerAttributes struct {
+	Relay           bool // Whether the relay is closed.
+	Momentary       bool // The relay opens again on its own after a short pulse.
+	SensorPresent   bool // Whether anything is wired to the sensor input.
+	SensorTriggered bool
+}
+
+// IsMaker reports whether the Device is a WeMo Maker.
+func (d *Device) IsMaker() bool {
+	return d.DeviceType == MakerType
+}
+
+var attributeListRe = regexp.MustCompile(`(?s)<attributeList>(.*)</attributeList>`)
+
+// MakerAttributes reads the relay and sensor state of a WeMo Maker.
+func (d *Device) MakerAttributes() (*MakerAttributes, error) {
+	if !d.IsMaker() {
+		return nil, fmt.Errorf("%s is not a maker", d.FriendlyName)
+	}
+	body, err := d.call("deviceevent1", deviceEventService, "GetAttributes", "")
+	if err != nil {
+		return nil, err
+	}
+
+	matches := attributeListRe.FindSubmatch(body)
+	if matches == nil {
+		return nil, fmt.Errorf("no attributes in response from %s", d.Host)
+	}
+	attrs, err := parseAttributes(string(matches[1]))
+	if err != nil {
+		return nil, err
+	}
+	return &MakerAttributes{
+		Relay:         attrs["Switch"] == 1,
+		Momentary:     attrs["SwitchMode"] == 1,
+		SensorPresent: attrs["SensorPresent"] == 1,
+		// The sensor input reads 0 when it is triggered (closed).
+		SensorTriggered: attrs["SensorPresent"] == 1 && attrs["Sensor"] == 0,
+	}, nil
+}
+
+// SetMomentary switches a WeMo Maker between toggle and momentary mode.
+// In momentary mode, turning the relay on closes it for a short pulse.
+func (d *Device) SetMomentary(momentary bool) error {
+	if !d.IsMaker() {
+		return fmt.Errorf("%s is not a maker", d.FriendlyName)
+	}
+	mode := 0
+	if momentary {
+		mode = 1
+	}
+	attr := fmt.Sprintf("<attribute><name>SwitchMode</name><value>%d</value></attribute>", mode)
+	args := "<attributeList>" + xmlEscape(attr) + "</attributeList>"
+	_, err := d.call("deviceevent1", deviceEventService, "SetAttributes", args)
+	return err
+}
+
+// parseAttributes parses the escaped attribute list used by the deviceevent
+// service into a map of attribute names to values.
+func parseAttributes(list string) (map[string]int, error) {
+	data := struct {
+		Attributes []struct {
+			Name  string `xml:"name"`
+			Value string `xml:"value"`
+		} `xml:"attribute"`
+	}{}
+	var unescaped string
+	if err := xml.Unmarshal([]byte("<a>"+list+"</a>"), &unescaped); err != nil {
+		return nil, err
+	}
+	if err := xml.Unmarshal([]byte("<a>"+unescaped+"</a>"), &data); err != nil {
+		return nil, err
+	}
+
+	attrs := map[string]int{}
+	for _, a := range data.Attributes {
+		v, err := strconv.Atoi(a.Value)
+		if err != nil {
+			continue // Not all attributes are numeric.
+		}
+		attrs[a.Name] = v
+	}
+	return attrs, nil
+}
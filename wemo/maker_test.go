@@ -0,0 +1,39 @@
+package wemo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			in: "&lt;attribute&gt;&lt;name&gt;Switch&lt;/name&gt;&lt;value&gt;1&lt;/value&gt;&lt;/attribute&gt;" +
+				"&lt;attribute&gt;&lt;name&gt;Sensor&lt;/name&gt;&lt;value&gt;0&lt;/value&gt;&lt;/attribute&gt;" +
+				"&lt;attribute&gt;&lt;name&gt;SwitchMode&lt;/name&gt;&lt;value&gt;1&lt;/value&gt;&lt;/attribute&gt;" +
+				"&lt;attribute&gt;&lt;name&gt;SensorPresent&lt;/name&gt;&lt;value&gt;1&lt;/value&gt;&lt;/attribute&gt;",
+			want: map[string]int{"Switch": 1, "Sensor": 0, "SwitchMode": 1, "SensorPresent": 1},
+		},
+		// Non-numeric attributes are skipped.
+		{
+			in: "&lt;attribute&gt;&lt;name&gt;Switch&lt;/name&gt;&lt;value&gt;0&lt;/value&gt;&lt;/attribute&gt;" +
+				"&lt;attribute&gt;&lt;name&gt;Mode&lt;/name&gt;&lt;value&gt;NA&lt;/value&gt;&lt;/attribute&gt;",
+			want: map[string]int{"Switch": 0},
+		},
+		{in: "", want: map[string]int{}},
+		{in: "&lt;attribute&gt;&lt;name&gt;Switch", wantErr: true},
+	} {
+		got, err := parseAttributes(tc.in)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseAttributes(%q) error = %v, want error: %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseAttributes(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
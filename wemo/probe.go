@@ -0,0 +1,113 @@
+package wemo
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Largest network ProbeNetwork scans, a /22.
+	maxProbeHosts = 1024
+	// Number of addresses probed at once.
+	probeWorkers = 64
+)
+
+// probeClient is quicker to give up than setupClient, most probed
+// addresses have no device.
+var probeClient = &http.Client{Timeout: time.Second}
+
+// ProbeNetwork finds devices of the given UPnP types by requesting setup.xml
+// on the known WeMo ports of every address in a CIDR network, such as
+// "192.168.20.0/24". It works where multicast discovery does not, at the
+// cost of many requests. Bridges are replaced by their bulbs.
+func ProbeNetwork(cidr string, types ...string) ([]*Device, error) {
+	ip, network, _, err := probeRange(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := map[string]bool{}
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	hosts := make(chan string)
+	go func() {
+		for a := ip; network.Contains(a); a = nextIP(a) {
+			for _, port := range ports {
+				hosts <- net.JoinHostPort(a.String(), strconv.Itoa(port))
+			}
+		}
+		close(hosts)
+	}()
+
+	devices := []*Device{}
+	mutex := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for i := 0; i < probeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range hosts {
+				d, err := newDevice(probeClient, host)
+				if err != nil || !wanted[d.DeviceType] {
+					continue
+				}
+				found := expandBridge(d)
+				mutex.Lock()
+				devices = append(devices, found...)
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return devices, nil
+}
+
+// ProbeTime is the longest ProbeNetwork can take to scan a CIDR network,
+// when none of its addresses answer.
+func ProbeTime(cidr string) (time.Duration, error) {
+	_, _, n, err := probeRange(cidr)
+	if err != nil {
+		return 0, err
+	}
+	requests := n * len(ports)
+	rounds := (requests + probeWorkers - 1) / probeWorkers
+	return time.Duration(rounds) * probeClient.Timeout, nil
+}
+
+// probeRange parses a CIDR network to probe, returning its first address
+// and its number of addresses.
+func probeRange(cidr string) (net.IP, *net.IPNet, int, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	ip = ip.Mask(network.Mask).To4()
+	if ip == nil {
+		return nil, nil, 0, fmt.Errorf("only IPv4 networks can be probed")
+	}
+	ones, bits := network.Mask.Size()
+	n := 1 << uint(bits-ones)
+	if n > maxProbeHosts {
+		return nil, nil, 0, fmt.Errorf("%s is too large to probe, the limit is %d addresses", cidr, maxProbeHosts)
+	}
+	return ip, network, n, nil
+}
+
+// nextIP returns the IPv4 address following ip.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
@@ -10,35 +10,101 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/huin/goupnp"
 )
 
 const (
-	setupURL = "http://%s/setup.xml"
-	stateURL = "http://%s/upnp/control/basicevent1"
+	setupURL   = "http://%s/setup.xml"
+	controlURL = "http://%s/upnp/control/%s"
 )
 
+// UPnP device types of the supported WeMo devices.
+const (
+	InsightType     = "urn:Belkin:device:insight:1"
+	ControlleeType  = "urn:Belkin:device:controllee:1"
+	DimmerType      = "urn:Belkin:device:dimmer:1"
+	LightSwitchType = "urn:Belkin:device:lightswitch:1"
+	MakerType       = "urn:Belkin:device:Maker:1"
+	MotionType      = "urn:Belkin:device:sensor:1"
+	BridgeType      = "urn:Belkin:device:bridge:1"
+)
+
+// BulbType is the DeviceType of bulbs behind a WeMo Link bridge.
+// It is not a UPnP type, bulbs are only reachable through their bridge.
+const BulbType = "bulb"
+
+// DefaultTypes are the device types DiscoverDevices searches for.
+// Callers may append to it to discover other devices speaking the same API.
+var DefaultTypes = []string{
+	InsightType,
+	ControlleeType,
+	DimmerType,
+	LightSwitchType,
+	MakerType,
+	MotionType,
+	BridgeType,
+}
+
+// setupClient fetches setup.xml. The timeout keeps probes of addresses
+// without a device from hanging.
+var setupClient = &http.Client{Timeout: 5 * time.Second}
+
+// controlClient invokes SOAP actions, the timeout keeps calls to devices
+// which went away from hanging.
+var controlClient = &http.Client{Timeout: 5 * time.Second}
+
 // Device models a WeMo device.
 type Device struct {
 	Host         string
 	FriendlyName string
+	DeviceType   string
+
+	// Metadata from setup.xml. The UDN uniquely identifies the Device.
+	UDN             string
+	SerialNumber    string
+	MACAddress      string
+	ModelName       string
+	ModelNumber     string
+	FirmwareVersion string
+	IconURL         string
+
+	bulb *bulb // Set for bulbs behind a WeMo Link bridge.
 }
 
 type deviceData struct {
-	FriendlyName string `xml:"friendlyName"`
+	FriendlyName    string `xml:"friendlyName"`
+	DeviceType      string `xml:"deviceType"`
+	UDN             string `xml:"UDN"`
+	SerialNumber    string `xml:"serialNumber"`
+	MACAddress      string `xml:"macAddress"`
+	ModelName       string `xml:"modelName"`
+	ModelNumber     string `xml:"modelNumber"`
+	FirmwareVersion string `xml:"firmwareVersion"`
+	Icons           []struct {
+		URL string `xml:"url"`
+	} `xml:"iconList>icon"`
 }
 
 // NewDevice sets up a new Device instance.
 // A connection is made to the device to lookup basic properties.
 func NewDevice(host string) (*Device, error) {
+	return newDevice(setupClient, host)
+}
+
+func newDevice(client *http.Client, host string) (*Device, error) {
 	url := fmt.Sprintf(setupURL, host)
-	resp, err := http.Get(url)
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s failed: %s", url, resp.Status)
+	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
@@ -52,104 +118,229 @@ func NewDevice(host string) (*Device, error) {
 		return nil, err
 	}
 
-	return &Device{
-		Host:         host,
-		FriendlyName: data.Device.FriendlyName,
-	}, nil
+	d := &Device{
+		Host:            host,
+		FriendlyName:    data.Device.FriendlyName,
+		DeviceType:      data.Device.DeviceType,
+		UDN:             data.Device.UDN,
+		SerialNumber:    data.Device.SerialNumber,
+		MACAddress:      data.Device.MACAddress,
+		ModelName:       data.Device.ModelName,
+		ModelNumber:     data.Device.ModelNumber,
+		FirmwareVersion: data.Device.FirmwareVersion,
+	}
+	if len(data.Device.Icons) > 0 {
+		// Icon URLs are relative to the device.
+		icon := strings.TrimPrefix(data.Device.Icons[0].URL, "/")
+		d.IconURL = fmt.Sprintf("http://%s/%s", host, icon)
+	}
+	return d, nil
 }
 
-// DiscoverDevices finds all the Wemo Switch or Insight Switches on the network.
+// DiscoverDevices finds all the devices of the DefaultTypes on the network.
+// WeMo Link bridges are not returned themselves, the bulbs paired with them
+// are returned instead.
 func DiscoverDevices() ([]*Device, error) {
+	return DiscoverTypes(DefaultTypes...)
+}
+
+// DiscoverTypes finds all the devices of the given UPnP device types on the
+// network.
+func DiscoverTypes(types ...string) ([]*Device, error) {
 	devices := []*Device{}
 
-	types := []string{
-		"urn:Belkin:device:insight:1",
-		"urn:Belkin:device:controllee:1",
-	}
 	for _, t := range types {
 		hosts, err := goupnp.DiscoverDevices(t)
 		if err != nil {
 			return nil, err
 		}
 		for _, host := range hosts {
-			d, err := NewDevice(host.Location.Host)
-			if err != nil {
-				log.Printf("unable to connect to %s: %v", host.Location.Host, err)
-				continue
-			}
-			devices = append(devices, d)
+			devices = append(devices, DevicesAt(host.Location.Host)...)
 		}
 	}
 	return devices, nil
 }
 
-const getBinaryStateMsg = `
-<?xml version="1.0" encoding="utf-8"?>
-<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
-  <s:Body>
-    <u:GetBinaryState xmlns:u="urn:Belkin:service:basicevent:1"></u:GetBinaryState>
-  </s:Body>
-</s:Envelope>
-`
+// DevicesAt connects to devices at known host:port addresses, for networks
+// where discovery does not work. Bridges are replaced by their bulbs, as in
+// DiscoverDevices. Hosts which cannot be reached are logged and skipped.
+func DevicesAt(hosts ...string) []*Device {
+	devices := []*Device{}
+	for _, host := range hosts {
+		d, err := NewDevice(host)
+		if err != nil {
+			log.Printf("unable to connect to %s: %v", host, err)
+			continue
+		}
+		devices = append(devices, expandBridge(d)...)
+	}
+	return devices
+}
+
+// expandBridge returns the bulbs of a bridge, or the Device itself if it is
+// not a bridge.
+func expandBridge(d *Device) []*Device {
+	if d.DeviceType != BridgeType {
+		return []*Device{d}
+	}
+	bulbs, err := d.Bulbs()
+	if err != nil {
+		log.Printf("unable to list bulbs of %s: %v", d.Host, err)
+		return nil
+	}
+	return bulbs
+}
+
+const basicEventService = "urn:Belkin:service:basicevent:1"
+
+var getStateRe = regexp.MustCompile(`<BinaryState>([^<]*)</BinaryState>`)
 
-var getStateRe = regexp.MustCompile(`.*<BinaryState>(\d+)</BinaryState>.*`)
+// PowerState is the detailed state of a Device.
+type PowerState int
+
+// Possible PowerStates. Standby is reported by Insight switches that are on
+// but whose load draws less than the standby threshold.
+const (
+	Unknown PowerState = iota
+	Off
+	On
+	Standby
+)
+
+func (p PowerState) String() string {
+	switch p {
+	case Off:
+		return "off"
+	case On:
+		return "on"
+	case Standby:
+		return "standby"
+	}
+	return "unknown"
+}
+
+// IsOn reports whether the Device is switched on, including standby.
+func (p PowerState) IsOn() bool {
+	return p == On || p == Standby
+}
 
-// State gets the state of the Device.
+// State gets the on/off state of the Device.
 // An error is returned if the state cannot be looked up.
 func (d *Device) State() (bool, error) {
-	msg := bytes.NewBuffer([]byte(getBinaryStateMsg))
-	url := fmt.Sprintf(stateURL, d.Host)
-	req, err := http.NewRequest("POST", url, msg)
+	p, err := d.PowerState()
 	if err != nil {
 		return false, err
 	}
-	req.Header.Set("Content-Type", "text/xml")
-	req.Header.Set("SOAPACTION", `"urn:Belkin:service:basicevent:1#GetBinaryState"`)
+	return p.IsOn(), nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return false, err
+// PowerState gets the detailed state of the Device.
+// An error is returned if the state cannot be looked up.
+func (d *Device) PowerState() (PowerState, error) {
+	if d.IsBulb() {
+		return d.bulbPowerState()
 	}
-	defer resp.Body.Close()
-	rbody, err := ioutil.ReadAll(resp.Body)
+	body, err := d.call("basicevent1", basicEventService, "GetBinaryState", "")
 	if err != nil {
-		return false, err
+		return Unknown, err
 	}
 
-	matches := getStateRe.FindStringSubmatch(string(rbody))
-	return strconv.ParseBool(matches[1])
+	matches := getStateRe.FindStringSubmatch(string(body))
+	if matches == nil {
+		return Unknown, fmt.Errorf("no state in response from %s", d.Host)
+	}
+	return parseBinaryState(matches[1])
 }
 
-const setBinaryStateMsg = `
-<?xml version="1.0" encoding="utf-8"?>
-<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
-  <s:Body>
-    <u:SetBinaryState xmlns:u="urn:Belkin:service:basicevent:1">
-      <BinaryState>%d</BinaryState>
-    </u:SetBinaryState>
-  </s:Body>
-</s:Envelope>
-`
+// parseBinaryState converts a BinaryState payload into a PowerState.
+// Some devices append extra fields separated by pipes, e.g. "1|1490000000|...",
+// only the first one holds the state.
+func parseBinaryState(s string) (PowerState, error) {
+	v, err := strconv.Atoi(strings.SplitN(s, "|", 2)[0])
+	if err != nil {
+		return Unknown, fmt.Errorf("unable to parse state %q: %v", s, err)
+	}
+	switch v {
+	case 0:
+		return Off, nil
+	case 1:
+		return On, nil
+	case 8:
+		return Standby, nil
+	}
+	return Unknown, fmt.Errorf("unknown state %q", s)
+}
+
+// ReadOnly reports whether the Device is a sensor which cannot be switched.
+// The state of a Motion sensor is on while it detects motion.
+func (d *Device) ReadOnly() bool {
+	return d.DeviceType == MotionType
+}
 
 // SetState sets the state of the device.
 // An error is returned if it fails to do so. In this author's experience,
 // errors are fairly common so retry logic should be used.
 func (d *Device) SetState(state bool) error {
+	if d.ReadOnly() {
+		return fmt.Errorf("%s is read-only", d.FriendlyName)
+	}
 	i := 0
 	if state {
 		i = 1
 	}
-	msg := bytes.NewBuffer([]byte(fmt.Sprintf(setBinaryStateMsg, i)))
-	url := fmt.Sprintf(stateURL, d.Host)
+	if d.IsBulb() {
+		return d.setBulbStatus(capOnOff, strconv.Itoa(i))
+	}
+	_, err := d.call("basicevent1", basicEventService, "SetBinaryState", fmt.Sprintf("<BinaryState>%d</BinaryState>", i))
+	return err
+}
+
+const soapMsg = `
+<?xml version="1.0" encoding="utf-8"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+  <s:Body>
+    <u:%[2]s xmlns:u="%[1]s">%[3]s</u:%[2]s>
+  </s:Body>
+</s:Envelope>
+`
+
+// call invokes a SOAP action on one of the Device's services and returns
+// the raw response body. args holds the already encoded action arguments.
+func (d *Device) call(control, service, action, args string) ([]byte, error) {
+	msg := bytes.NewBuffer([]byte(fmt.Sprintf(soapMsg, service, action, args)))
+	url := fmt.Sprintf(controlURL, d.Host, control)
 	req, err := http.NewRequest("POST", url, msg)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "text/xml")
-	req.Header.Set("SOAPACTION", `"urn:Belkin:service:basicevent:1#SetBinaryState"`)
+	req.Header.Set("SOAPACTION", fmt.Sprintf(`"%s#%s"`, service, action))
 
-	client := &http.Client{}
-	_, err = client.Do(req)
+	resp, err := controlClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s on %s failed: %s", action, d.Host, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// xmlEscape escapes s for use as the value of a SOAP argument.
+func xmlEscape(s string) string {
+	b := &bytes.Buffer{}
+	xml.EscapeText(b, []byte(s))
+	return b.String()
+}
+
+// SetFriendlyName changes the name stored on the Device.
+// The Device's FriendlyName field is not updated.
+func (d *Device) SetFriendlyName(name string) error {
+	if d.IsBulb() {
+		return fmt.Errorf("renaming bulbs is not supported")
+	}
+	args := fmt.Sprintf("<FriendlyName>%s</FriendlyName>", xmlEscape(name))
+	_, err := d.call("basicevent1", basicEventService, "ChangeFriendlyName", args)
 	return err
 }
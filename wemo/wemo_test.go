@@ -0,0 +1,30 @@
+package wemo
+
+import "testing"
+
+func TestParseBinaryState(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    PowerState
+		wantErr bool
+	}{
+		{in: "0", want: Off},
+		{in: "1", want: On},
+		{in: "8", want: Standby},
+		{in: "1|1490000000|0|0|0|1209600|0|0|0|0|8000", want: On},
+		{in: "8|1490000000|20|1234|56789|1209600|10|3000|72000|9000000|8000", want: Standby},
+		{in: "", wantErr: true},
+		{in: "on", wantErr: true},
+		{in: "2", wantErr: true},
+		{in: "Error", wantErr: true},
+	} {
+		got, err := parseBinaryState(tc.in)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseBinaryState(%q) error = %v, want error: %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseBinaryState(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}